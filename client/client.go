@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"tstore/data"
+	"tstore/database"
 	"tstore/mutation"
 	"tstore/proto"
 	"tstore/query"
@@ -14,6 +16,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Endpoint struct {
@@ -54,6 +57,18 @@ func (c *Client) DeleteDatabase(name string) error {
 	return err
 }
 
+// ForkDatabase creates a database which starts as a copy of the source database at the commit.
+func (c *Client) ForkDatabase(sourceName string, commitRef data.CommitRef, name string) error {
+	ctx := context.Background()
+	_, err := c.databaseClient.ForkDatabase(ctx, &proto.ForkDatabaseRequest{
+		SourceName:    sourceName,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
+		Name:          name,
+	})
+	return err
+}
+
 func (c *Client) CreateTransaction(dbName string, transactionInput mutation.TransactionInput) error {
 	ctx := context.Background()
 	protoTransaction := proto.ToProtoTransaction(transactionInput)
@@ -74,12 +89,58 @@ func (c *Client) GetLatestCommit(dbName string) (data.Commit, error) {
 	return proto.FromProtoCommit(commit), nil
 }
 
-func (c *Client) QueryEntities(dbName string, transactionID uint64, collector lang.Collector) ([]data.Entity, error) {
+// ListCommits streams the commit log, query.PageSize 0 streams every commit in the range.
+// The next page starts after the last streamed commit, with the ID of that commit as query.PageToken.
+func (c *Client) ListCommits(dbName string, query data.CommitLogQuery) (*CommitIterator, error) {
+	request := &proto.ListCommitsRequest{
+		DbName:             dbName,
+		BeginTransactionId: query.BeginCommitID,
+		EndTransactionId:   query.EndCommitID,
+		BeginTag:           query.BeginTag,
+		EndTag:             query.EndTag,
+		NewestFirst:        query.NewestFirst,
+		PageToken:          query.PageToken,
+		PageSize:           uint64(query.PageSize),
+	}
+	if !query.BeginTime.IsZero() {
+		request.BeginTime = timestamppb.New(query.BeginTime)
+	}
+
+	if !query.EndTime.IsZero() {
+		request.EndTime = timestamppb.New(query.EndTime)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.databaseClient.ListCommits(ctx, request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &CommitIterator{stream: stream, cancel: cancel}, nil
+}
+
+// GetCommitAt returns the latest commit made at or before the time, the zero Commit when there is none.
+func (c *Client) GetCommitAt(dbName string, at time.Time) (data.Commit, error) {
+	ctx := context.Background()
+	commit, err := c.databaseClient.GetCommitAt(ctx, &proto.GetCommitAtRequest{
+		DbName: dbName,
+		At:     timestamppb.New(at),
+	})
+	if err != nil {
+		return data.Commit{}, err
+	}
+
+	return proto.FromProtoCommit(commit), nil
+}
+
+func (c *Client) QueryEntities(dbName string, commitRef data.CommitRef, collector lang.Collector) ([]data.Entity, error) {
 	protoExpression := proto.ToProtoExpression(lang.Expression(collector))
 	ctx := context.Background()
 	entities, err := c.databaseClient.QueryEntitiesAtCommit(ctx, &proto.QueryAtCommitRequest{
 		DbName:        dbName,
-		TransactionId: transactionID,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
 		Query:         protoExpression,
 	})
 	if err != nil {
@@ -89,13 +150,221 @@ func (c *Client) QueryEntities(dbName string, transactionID uint64, collector la
 	return proto.FromProtoEntities(entities)
 }
 
-func (c *Client) QueryEntityGroups(dbName string, transactionID uint64, collector lang.GroupCollector) (query.Groups[data.Entity], error) {
+func (c *Client) QueryEntityGroups(
+	dbName string,
+	commitRef data.CommitRef,
+	collector lang.GroupCollector,
+) (query.Groups[data.Entity], error) {
 	protoExpression := proto.ToProtoExpression(lang.Expression(collector))
 	ctx := context.Background()
 	groups, err := c.databaseClient.QueryEntityGroupsAtCommit(ctx, &proto.QueryAtCommitRequest{
 		DbName:        dbName,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
+		Query:         protoExpression,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromProtoGroups(groups)
+}
+
+func (c *Client) QueryEntitiesAsOf(dbName string, at time.Time, collector lang.Collector) ([]data.Entity, error) {
+	protoExpression := proto.ToProtoExpression(lang.Expression(collector))
+	ctx := context.Background()
+	entities, err := c.databaseClient.QueryEntitiesAsOf(ctx, &proto.QueryAsOfRequest{
+		DbName: dbName,
+		At:     timestamppb.New(at),
+		Query:  protoExpression,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromProtoEntities(entities)
+}
+
+func (c *Client) QueryEntityGroupsAsOf(
+	dbName string,
+	at time.Time,
+	collector lang.GroupCollector,
+) (query.Groups[data.Entity], error) {
+	protoExpression := proto.ToProtoExpression(lang.Expression(collector))
+	ctx := context.Background()
+	groups, err := c.databaseClient.QueryEntityGroupsAsOf(ctx, &proto.QueryAsOfRequest{
+		DbName: dbName,
+		At:     timestamppb.New(at),
+		Query:  protoExpression,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromProtoGroups(groups)
+}
+
+func (c *Client) BlameEntity(dbName string, commitRef data.CommitRef, entityID uint64) (data.EntityBlame, error) {
+	ctx := context.Background()
+	blame, err := c.databaseClient.BlameEntity(ctx, &proto.BlameEntityRequest{
+		DbName:        dbName,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
+		EntityId:      entityID,
+	})
+	if err != nil {
+		return data.EntityBlame{}, err
+	}
+
+	return proto.FromProtoEntityBlame(blame)
+}
+
+// DiffCommits compares the data at 2 commits, lang.All compares every entity.
+func (c *Client) DiffCommits(
+	dbName string,
+	fromCommitRef data.CommitRef,
+	toCommitRef data.CommitRef,
+	filter lang.Filter,
+) (data.CommitDiff, error) {
+	ctx := context.Background()
+	diff, err := c.databaseClient.DiffCommits(ctx, &proto.DiffCommitsRequest{
+		DbName:            dbName,
+		FromTransactionId: fromCommitRef.CommitID,
+		FromTag:           fromCommitRef.Tag,
+		ToTransactionId:   toCommitRef.CommitID,
+		ToTag:             toCommitRef.Tag,
+		Filter:            proto.ToProtoExpression(lang.Expression(filter)),
+	})
+	if err != nil {
+		return data.CommitDiff{}, err
+	}
+
+	return proto.FromProtoCommitDiff(diff)
+}
+
+// CreateTag names a commit, the tag can't be moved afterwards.
+func (c *Client) CreateTag(dbName string, name string, transactionID uint64) error {
+	ctx := context.Background()
+	_, err := c.databaseClient.CreateTag(ctx, &proto.CreateTagRequest{
+		DbName:        dbName,
+		Name:          name,
 		TransactionId: transactionID,
+	})
+	return err
+}
+
+// ResolveTag returns the transaction ID of the commit named by the tag.
+func (c *Client) ResolveTag(dbName string, name string) (uint64, error) {
+	ctx := context.Background()
+	tag, err := c.databaseClient.ResolveTag(ctx, &proto.ResolveTagRequest{
+		DbName: dbName,
+		Name:   name,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.TransactionId, nil
+}
+
+func (c *Client) ListTags(dbName string) ([]data.Tag, error) {
+	ctx := context.Background()
+	tags, err := c.databaseClient.ListTags(ctx, &proto.ListTagsRequest{DbName: dbName})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromProtoTags(tags), nil
+}
+
+// CreateBranch creates a branch from a commit of main.
+func (c *Client) CreateBranch(dbName string, name string, commitRef data.CommitRef) error {
+	ctx := context.Background()
+	_, err := c.databaseClient.CreateBranch(ctx, &proto.CreateBranchRequest{
+		DbName:        dbName,
+		Name:          name,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
+	})
+	return err
+}
+
+func (c *Client) ListBranches(dbName string) ([]database.Branch, error) {
+	ctx := context.Background()
+	branches, err := c.databaseClient.ListBranches(ctx, &proto.ListBranchesRequest{DbName: dbName})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromProtoBranches(branches), nil
+}
+
+// CreateBranchTransaction commits the transaction to the branch without affecting main.
+func (c *Client) CreateBranchTransaction(
+	dbName string,
+	branchName string,
+	transactionInput mutation.TransactionInput,
+) error {
+	ctx := context.Background()
+	protoTransaction := proto.ToProtoTransaction(transactionInput)
+	_, err := c.databaseClient.CreateTransaction(ctx, &proto.CreateTransactionRequest{
+		DbName:      dbName,
+		Transaction: protoTransaction,
+		Branch:      branchName,
+	})
+	return err
+}
+
+// GetBranchHead returns the latest commit of the branch.
+func (c *Client) GetBranchHead(dbName string, branchName string) (data.Commit, error) {
+	ctx := context.Background()
+	commit, err := c.databaseClient.GetLatestCommit(ctx, &proto.GetLatestCommitRequest{
+		DbName: dbName,
+		Branch: branchName,
+	})
+	if err != nil {
+		return data.Commit{}, err
+	}
+
+	return proto.FromProtoCommit(commit), nil
+}
+
+func (c *Client) QueryBranchEntities(
+	dbName string,
+	branchName string,
+	commitRef data.CommitRef,
+	collector lang.Collector,
+) ([]data.Entity, error) {
+	protoExpression := proto.ToProtoExpression(lang.Expression(collector))
+	ctx := context.Background()
+	entities, err := c.databaseClient.QueryEntitiesAtCommit(ctx, &proto.QueryAtCommitRequest{
+		DbName:        dbName,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
 		Query:         protoExpression,
+		Branch:        branchName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromProtoEntities(entities)
+}
+
+func (c *Client) QueryBranchEntityGroups(
+	dbName string,
+	branchName string,
+	commitRef data.CommitRef,
+	collector lang.GroupCollector,
+) (query.Groups[data.Entity], error) {
+	protoExpression := proto.ToProtoExpression(lang.Expression(collector))
+	ctx := context.Background()
+	groups, err := c.databaseClient.QueryEntityGroupsAtCommit(ctx, &proto.QueryAtCommitRequest{
+		DbName:        dbName,
+		TransactionId: commitRef.CommitID,
+		Tag:           commitRef.Tag,
+		Query:         protoExpression,
+		Branch:        branchName,
 	})
 	if err != nil {
 		return nil, err
@@ -104,10 +373,97 @@ func (c *Client) QueryEntityGroups(dbName string, transactionID uint64, collecto
 	return proto.FromProtoGroups(groups)
 }
 
+// MergeBranch merges the changes made on the branch since its last merge into main as a single commit.
+// Nothing is committed when the result has conflicts, they are resolved by a follow-up transaction
+// on either side before merging again.
+func (c *Client) MergeBranch(dbName string, branchName string, metadata data.CommitMetadata) (data.MergeResult, error) {
+	ctx := context.Background()
+	result, err := c.databaseClient.MergeBranch(ctx, &proto.MergeBranchRequest{
+		DbName:   dbName,
+		Name:     branchName,
+		Metadata: proto.ToProtoCommitMetadata(metadata),
+	})
+	if err != nil {
+		return data.MergeResult{}, err
+	}
+
+	return proto.FromProtoMergeResult(result)
+}
+
+func (c *Client) GetRetentionPolicy(dbName string) (data.RetentionPolicy, error) {
+	ctx := context.Background()
+	policy, err := c.databaseClient.GetRetentionPolicy(ctx, &proto.GetRetentionPolicyRequest{DbName: dbName})
+	if err != nil {
+		return data.RetentionPolicy{}, err
+	}
+
+	return proto.FromProtoRetentionPolicy(policy), nil
+}
+
+func (c *Client) SetRetentionPolicy(dbName string, policy data.RetentionPolicy) error {
+	ctx := context.Background()
+	_, err := c.databaseClient.SetRetentionPolicy(ctx, &proto.SetRetentionPolicyRequest{
+		DbName: dbName,
+		Policy: proto.ToProtoRetentionPolicy(policy),
+	})
+	return err
+}
+
 func (c *Client) Close() error {
 	return c.grpcConn.Close()
 }
 
+// CommitIterator receives the commits streamed by ListCommits.
+//
+//	for iterator.Next() {
+//		iterator.Commit()
+//	}
+//	err := iterator.Err()
+type CommitIterator struct {
+	stream proto.Database_ListCommitsClient
+	cancel context.CancelFunc
+	commit data.Commit
+	err    error
+	closed bool
+}
+
+func (c *CommitIterator) Next() bool {
+	if c.closed || c.err != nil {
+		return false
+	}
+
+	protoCommit, err := c.stream.Recv()
+	if err == io.EOF {
+		c.Close()
+		return false
+	}
+
+	if err != nil {
+		c.err = err
+		c.cancel()
+		return false
+	}
+
+	c.commit = proto.FromProtoCommit(protoCommit)
+	return true
+}
+
+func (c *CommitIterator) Commit() data.Commit {
+	return c.commit
+}
+
+func (c *CommitIterator) Err() error {
+	return c.err
+}
+
+func (c *CommitIterator) Close() error {
+	c.closed = true
+	c.cancel()
+	return nil
+}
+
+var _ io.Closer = (*CommitIterator)(nil)
+
 func NewClient(endpoint Endpoint) (*Client, error) {
 	conn, err := grpc.Dial(endpoint.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
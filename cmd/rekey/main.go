@@ -0,0 +1,81 @@
+// Command rekey re-encrypts an existing store with the active key of a master key file.
+// The server must be stopped while it runs.
+//
+// Rotate the master key and re-encrypt every value:
+//
+//	go run ./cmd/rekey -data-dir ./userData -key-file ./keys.json -rotate
+//
+// Encrypt a store written without encryption, creating the key file when it does not exist:
+//
+//	go run ./cmd/rekey -data-dir ./userData -key-file ./keys.json
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"tstore/server"
+	"tstore/storage"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	config := server.DefaultConfig()
+	dataDir := flag.String("data-dir", config.DataDir, "data directory of the store")
+	storageEngine := flag.String("engine", string(config.StorageEngine), "storage engine of the store: file, page or log")
+	keyFile := flag.String("key-file", "", "master key file")
+	rotate := flag.Bool("rotate", false, "generate a new master key and make it the active key before re-encrypting")
+	flag.Parse()
+
+	if *keyFile == "" {
+		log.Fatalln("-key-file is required")
+	}
+
+	keys, err := storage.LoadEncryptionKeys(*keyFile)
+	if os.IsNotExist(err) {
+		*rotate = true
+		err = nil
+	}
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if *rotate {
+		keyID, err := keys.Rotate()
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		// the new key has to be saved before any value is encrypted with it
+		err = keys.Save(*keyFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		log.Printf("active key: keyID=%v\n", keyID)
+	}
+
+	config.DataDir = *dataDir
+	config.StorageEngine = server.StorageEngine(*storageEngine)
+	rawMap, err := server.OpenStorageEngine(config)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	count, err := storage.Rekey(rawMap, keys)
+	if closer, ok := rawMap.(io.Closer); ok {
+		closeErr := closer.Close()
+		if closeErr != nil {
+			log.Println(closeErr)
+		}
+	}
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	log.Printf("re-encrypted values: count=%v\n", count)
+}
@@ -0,0 +1,86 @@
+package data
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"tstore/history"
+)
+
+// AttributeBlame tells which commit produced the current value of an attribute.
+type AttributeBlame struct {
+	Attribute   string                `json:"attribute"`
+	Value       interface{}           `json:"value"`
+	CommitID    uint64                `json:"commit_id"`
+	CommittedAt time.Time             `json:"committed_at"`
+	Status      history.VersionStatus `json:"status"`
+}
+
+type EntityBlame struct {
+	EntityID   uint64 `json:"entity_id"`
+	SchemaName string `json:"schema_name"`
+	// Attributes are sorted by attribute name
+	Attributes []AttributeBlame `json:"attributes"`
+}
+
+// BlameEntity returns the commit which produced each attribute of the entity at the commit,
+// false when the entity is not present at the commit.
+func (w WithVersion) BlameEntity(commitID uint64, entityID uint64) (EntityBlame, bool, error) {
+	err := w.CheckRetained(commitID)
+	if err != nil {
+		return EntityBlame{}, false, err
+	}
+
+	entity, exist, err := w.EntityHistories.FindLatestValueAt(commitID, entityID)
+	if err != nil || !exist {
+		return EntityBlame{}, false, err
+	}
+
+	valueHistory, exist, err := w.EntityHistories.FindValueHistory(entityID)
+	if err != nil || !exist {
+		return EntityBlame{}, false, err
+	}
+
+	entityValueHistory, ok := valueHistory.(EntityValueHistory)
+	if !ok {
+		err = fmt.Errorf("unexpected entity value history: %T", valueHistory)
+		log.Println(err)
+		return EntityBlame{}, false, err
+	}
+
+	versions, err := entityValueHistory.attributesHistory.ListAllLatestVersionsAt(commitID)
+	if err != nil {
+		log.Println(err)
+		return EntityBlame{}, false, err
+	}
+
+	blame := EntityBlame{
+		EntityID:   entityID,
+		SchemaName: entity.SchemaName,
+		Attributes: make([]AttributeBlame, 0, len(versions)),
+	}
+	for attribute, version := range versions {
+		// versions of transactions still being committed have no commit yet
+		commit, _, err := w.FindCommit(version.CommitID)
+		if err != nil {
+			log.Println(err)
+			return EntityBlame{}, false, err
+		}
+
+		blame.Attributes = append(blame.Attributes, AttributeBlame{
+			Attribute:   attribute,
+			Value:       version.Value,
+			CommitID:    version.CommitID,
+			CommittedAt: commit.CommittedAt,
+			Status:      version.Status,
+		})
+	}
+
+	sort.Slice(blame.Attributes, func(i, j int) bool {
+		return blame.Attributes[i].Attribute < blame.Attributes[j].Attribute
+	})
+
+	return blame, true, nil
+}
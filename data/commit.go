@@ -1,10 +1,49 @@
 package data
 
 import (
+	"sort"
 	"time"
 )
 
 type Commit struct {
 	CommittedTransactionID uint64    `json:"committed_transaction_id"`
 	CommittedAt            time.Time `json:"committed_at"`
+	// Summary is empty for the commits made before summaries were recorded
+	Summary  CommitSummary  `json:"summary"`
+	Metadata CommitMetadata `json:"metadata"`
+}
+
+// CommitMetadata records who made a commit and why, every field is optional.
+type CommitMetadata struct {
+	Author  string            `json:"author"`
+	Message string            `json:"message"`
+	Labels  map[string]string `json:"labels"`
+}
+
+type CommitSummary struct {
+	MutationCounts map[MutationType]int `json:"mutation_counts"`
+	// Schemas are the sorted names of the schemas touched by the commit
+	Schemas []string `json:"schemas"`
+}
+
+// NewCommitSummary summarizes the mutations of a transaction, keyed by schema name.
+func NewCommitSummary(mutations map[string][]Mutation) CommitSummary {
+	summary := CommitSummary{
+		MutationCounts: make(map[MutationType]int),
+		Schemas:        make([]string, 0, len(mutations)),
+	}
+
+	for schemaName, schemaMutations := range mutations {
+		if len(schemaMutations) == 0 {
+			continue
+		}
+
+		summary.Schemas = append(summary.Schemas, schemaName)
+		for _, mutation := range schemaMutations {
+			summary.MutationCounts[mutation.Type]++
+		}
+	}
+
+	sort.Strings(summary.Schemas)
+	return summary
 }
@@ -0,0 +1,137 @@
+package data
+
+import (
+	"log"
+	"math"
+	"time"
+)
+
+const defaultCommitLogPageSize = 100
+
+// CommitLogQuery selects the commits within [BeginCommitID, EndCommitID] made within [BeginTime, EndTime].
+// The zero value of each bound leaves the range open on that side.
+// BeginTag & EndTag, when set, take the place of BeginCommitID & EndCommitID.
+type CommitLogQuery struct {
+	BeginCommitID uint64
+	EndCommitID   uint64
+	BeginTag      string
+	EndTag        string
+	BeginTime     time.Time
+	EndTime       time.Time
+	NewestFirst   bool
+	// PageToken is the NextPageToken of the previous page, 0 for the 1st page
+	PageToken uint64
+	// PageSize defaults to 100 when it is not positive
+	PageSize int
+}
+
+type CommitLogPage struct {
+	Commits []Commit `json:"commits"`
+	// NextPageToken is 0 when there are no more commits
+	NextPageToken uint64 `json:"next_page_token"`
+}
+
+// ListCommits returns a page of the commits selected by the query, ordered by commit ID.
+func (w WithVersion) ListCommits(query CommitLogQuery) (CommitLogPage, error) {
+	beginCommitID, endCommitID, ok, err := w.commitIDRange(query)
+	if err != nil {
+		log.Println(err)
+		return CommitLogPage{}, err
+	}
+
+	page := CommitLogPage{Commits: make([]Commit, 0)}
+	if !ok {
+		return page, nil
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultCommitLogPageSize
+	}
+
+	iterator := w.commitIndex.AscendFrom(beginCommitID)
+	if query.NewestFirst {
+		iterator = w.commitIndex.DescendFrom(endCommitID)
+	}
+	defer iterator.Close()
+
+	for iterator.Next() {
+		commitID := iterator.Key()
+		if commitID < beginCommitID || commitID > endCommitID {
+			break
+		}
+
+		commit := iterator.Value()
+		if !query.BeginTime.IsZero() && commit.CommittedAt.Before(query.BeginTime) ||
+			!query.EndTime.IsZero() && commit.CommittedAt.After(query.EndTime) {
+			continue
+		}
+
+		if len(page.Commits) == pageSize {
+			page.NextPageToken = page.Commits[pageSize-1].CommittedTransactionID
+			break
+		}
+
+		page.Commits = append(page.Commits, commit)
+	}
+
+	if iterator.Err() != nil {
+		log.Println(iterator.Err())
+		return CommitLogPage{}, iterator.Err()
+	}
+
+	return page, nil
+}
+
+// commitIDRange narrows down the commit IDs to scan with the time bounds & the page token,
+// false when no commit can be selected
+func (w WithVersion) commitIDRange(query CommitLogQuery) (uint64, uint64, bool, error) {
+	beginCommitID, err := w.ResolveCommitRef(CommitRef{CommitID: query.BeginCommitID, Tag: query.BeginTag})
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	endCommitID, err := w.ResolveCommitRef(CommitRef{CommitID: query.EndCommitID, Tag: query.EndTag})
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	if endCommitID == 0 {
+		endCommitID = math.MaxUint64
+	}
+
+	if !query.BeginTime.IsZero() {
+		// the commits are made in the order of their IDs
+		commit, found, err := w.CommitAt(query.BeginTime.Add(-time.Nanosecond))
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		if found && commit.CommittedTransactionID >= beginCommitID {
+			beginCommitID = commit.CommittedTransactionID + 1
+		}
+	}
+
+	if !query.EndTime.IsZero() {
+		commit, found, err := w.CommitAt(query.EndTime)
+		if err != nil || !found {
+			return 0, 0, false, err
+		}
+
+		if commit.CommittedTransactionID < endCommitID {
+			endCommitID = commit.CommittedTransactionID
+		}
+	}
+
+	if query.PageToken > 0 {
+		if query.NewestFirst {
+			if query.PageToken-1 < endCommitID {
+				endCommitID = query.PageToken - 1
+			}
+		} else if query.PageToken+1 > beginCommitID {
+			beginCommitID = query.PageToken + 1
+		}
+	}
+
+	return beginCommitID, endCommitID, beginCommitID <= endCommitID, nil
+}
@@ -0,0 +1,336 @@
+package data
+
+import (
+	"log"
+	"reflect"
+	"sort"
+
+	"tstore/history"
+)
+
+type AttributeDiff struct {
+	Attribute string                `json:"attribute"`
+	OldValue  interface{}           `json:"old_value"`
+	NewValue  interface{}           `json:"new_value"`
+	Status    history.VersionStatus `json:"status"`
+}
+
+type EntityDiff struct {
+	EntityID   uint64                `json:"entity_id"`
+	SchemaName string                `json:"schema_name"`
+	Status     history.VersionStatus `json:"status"`
+	// Attributes are sorted by attribute name
+	Attributes []AttributeDiff `json:"attributes"`
+}
+
+type SchemaAttributeDiff struct {
+	Attribute string                `json:"attribute"`
+	OldType   Type                  `json:"old_type"`
+	NewType   Type                  `json:"new_type"`
+	Status    history.VersionStatus `json:"status"`
+}
+
+type SchemaDiff struct {
+	SchemaName string                `json:"schema_name"`
+	Status     history.VersionStatus `json:"status"`
+	// Attributes are sorted by attribute name
+	Attributes []SchemaAttributeDiff `json:"attributes"`
+}
+
+// CommitDiff is what changed from one commit to another.
+// Entities are sorted by entity ID and schemas are sorted by schema name.
+type CommitDiff struct {
+	Entities []EntityDiff `json:"entities"`
+	Schemas  []SchemaDiff `json:"schemas"`
+}
+
+// DiffCommits compares the data at fromCommitID with the data at toCommitID.
+// An entity is included when filter accepts either its old or its new state.
+func (w WithVersion) DiffCommits(
+	fromCommitID uint64,
+	toCommitID uint64,
+	filter func(entity Entity) bool,
+) (CommitDiff, error) {
+	err := w.CheckRetained(fromCommitID)
+	if err != nil {
+		return CommitDiff{}, err
+	}
+
+	err = w.CheckRetained(toCommitID)
+	if err != nil {
+		return CommitDiff{}, err
+	}
+
+	entityDiffs, err := w.diffEntities(fromCommitID, toCommitID, filter)
+	if err != nil {
+		log.Println(err)
+		return CommitDiff{}, err
+	}
+
+	schemaDiffs, err := w.diffSchemas(fromCommitID, toCommitID)
+	if err != nil {
+		log.Println(err)
+		return CommitDiff{}, err
+	}
+
+	return CommitDiff{
+		Entities: entityDiffs,
+		Schemas:  schemaDiffs,
+	}, nil
+}
+
+func (w WithVersion) diffEntities(
+	fromCommitID uint64,
+	toCommitID uint64,
+	filter func(entity Entity) bool,
+) ([]EntityDiff, error) {
+	oldEntities, newEntities, err := w.changedEntities(fromCommitID, toCommitID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	entityIDs := make(map[uint64]bool)
+	for entityID := range oldEntities {
+		entityIDs[entityID] = true
+	}
+
+	for entityID := range newEntities {
+		entityIDs[entityID] = true
+	}
+
+	diffs := make([]EntityDiff, 0)
+	for entityID := range entityIDs {
+		oldEntity, oldExist := oldEntities[entityID]
+		newEntity, newExist := newEntities[entityID]
+		if !(oldExist && filter(oldEntity)) && !(newExist && filter(newEntity)) {
+			continue
+		}
+
+		diff, changed := diffEntity(entityID, oldEntity, oldExist, newEntity, newExist)
+		if changed {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].EntityID < diffs[j].EntityID
+	})
+
+	return diffs, nil
+}
+
+// changedEntities returns the entities which may differ between the commits at each of the commits.
+// The changes recorded for the snapshots narrow down the entities to compare when they cover the commits.
+func (w WithVersion) changedEntities(
+	fromCommitID uint64,
+	toCommitID uint64,
+) (map[uint64]Entity, map[uint64]Entity, error) {
+	beginCommitID, endCommitID := fromCommitID, toCommitID
+	if beginCommitID > endCommitID {
+		beginCommitID, endCommitID = endCommitID, beginCommitID
+	}
+
+	entityIDs, recorded, err := w.snapshots.changedEntityIDsAfter(beginCommitID, endCommitID)
+	if err != nil {
+		log.Println(err)
+		return nil, nil, err
+	}
+
+	if !recorded {
+		oldEntities, _, err := w.EntityHistories.ListAllLatestValuesAt(fromCommitID)
+		if err != nil {
+			log.Println(err)
+			return nil, nil, err
+		}
+
+		newEntities, _, err := w.EntityHistories.ListAllLatestValuesAt(toCommitID)
+		if err != nil {
+			log.Println(err)
+			return nil, nil, err
+		}
+
+		return oldEntities, newEntities, nil
+	}
+
+	oldEntities := make(map[uint64]Entity)
+	newEntities := make(map[uint64]Entity)
+	for _, entityID := range entityIDs {
+		oldEntity, exist, err := w.EntityHistories.FindLatestValueAt(fromCommitID, entityID)
+		if err != nil {
+			log.Println(err)
+			return nil, nil, err
+		}
+
+		if exist {
+			oldEntities[entityID] = oldEntity
+		}
+
+		newEntity, exist, err := w.EntityHistories.FindLatestValueAt(toCommitID, entityID)
+		if err != nil {
+			log.Println(err)
+			return nil, nil, err
+		}
+
+		if exist {
+			newEntities[entityID] = newEntity
+		}
+	}
+
+	return oldEntities, newEntities, nil
+}
+
+func (w WithVersion) diffSchemas(fromCommitID uint64, toCommitID uint64) ([]SchemaDiff, error) {
+	oldSchemas, _, err := w.SchemaHistories.ListAllLatestValuesAt(fromCommitID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	newSchemas, _, err := w.SchemaHistories.ListAllLatestValuesAt(toCommitID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	schemaNames := make(map[string]bool)
+	for schemaName := range oldSchemas {
+		schemaNames[schemaName] = true
+	}
+
+	for schemaName := range newSchemas {
+		schemaNames[schemaName] = true
+	}
+
+	diffs := make([]SchemaDiff, 0)
+	for schemaName := range schemaNames {
+		oldSchema, oldExist := oldSchemas[schemaName]
+		newSchema, newExist := newSchemas[schemaName]
+		diff, changed := diffSchema(schemaName, oldSchema, oldExist, newSchema, newExist)
+		if changed {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].SchemaName < diffs[j].SchemaName
+	})
+
+	return diffs, nil
+}
+
+func diffEntity(
+	entityID uint64,
+	oldEntity Entity,
+	oldExist bool,
+	newEntity Entity,
+	newExist bool,
+) (EntityDiff, bool) {
+	diff := EntityDiff{
+		EntityID:   entityID,
+		SchemaName: newEntity.SchemaName,
+		Status:     versionStatus(oldExist, newExist),
+		Attributes: make([]AttributeDiff, 0),
+	}
+	if !newExist {
+		diff.SchemaName = oldEntity.SchemaName
+	}
+
+	for attribute, oldValue := range oldEntity.Attributes {
+		newValue, exist := newEntity.Attributes[attribute]
+		if !exist {
+			diff.Attributes = append(diff.Attributes, AttributeDiff{
+				Attribute: attribute,
+				OldValue:  oldValue,
+				Status:    history.DeletedVersionStatus,
+			})
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			diff.Attributes = append(diff.Attributes, AttributeDiff{
+				Attribute: attribute,
+				OldValue:  oldValue,
+				NewValue:  newValue,
+				Status:    history.UpdatedVersionStatus,
+			})
+		}
+	}
+
+	for attribute, newValue := range newEntity.Attributes {
+		if _, exist := oldEntity.Attributes[attribute]; !exist {
+			diff.Attributes = append(diff.Attributes, AttributeDiff{
+				Attribute: attribute,
+				NewValue:  newValue,
+				Status:    history.CreatedVersionStatus,
+			})
+		}
+	}
+
+	sort.Slice(diff.Attributes, func(i, j int) bool {
+		return diff.Attributes[i].Attribute < diff.Attributes[j].Attribute
+	})
+
+	changed := oldExist != newExist ||
+		oldEntity.SchemaName != newEntity.SchemaName ||
+		len(diff.Attributes) > 0
+	return diff, changed
+}
+
+func diffSchema(
+	schemaName string,
+	oldSchema Schema,
+	oldExist bool,
+	newSchema Schema,
+	newExist bool,
+) (SchemaDiff, bool) {
+	diff := SchemaDiff{
+		SchemaName: schemaName,
+		Status:     versionStatus(oldExist, newExist),
+		Attributes: make([]SchemaAttributeDiff, 0),
+	}
+
+	for attribute, oldType := range oldSchema.Attributes {
+		newType, exist := newSchema.Attributes[attribute]
+		if !exist {
+			diff.Attributes = append(diff.Attributes, SchemaAttributeDiff{
+				Attribute: attribute,
+				OldType:   oldType,
+				NewType:   NoneDataType,
+				Status:    history.DeletedVersionStatus,
+			})
+		} else if oldType != newType {
+			diff.Attributes = append(diff.Attributes, SchemaAttributeDiff{
+				Attribute: attribute,
+				OldType:   oldType,
+				NewType:   newType,
+				Status:    history.UpdatedVersionStatus,
+			})
+		}
+	}
+
+	for attribute, newType := range newSchema.Attributes {
+		if _, exist := oldSchema.Attributes[attribute]; !exist {
+			diff.Attributes = append(diff.Attributes, SchemaAttributeDiff{
+				Attribute: attribute,
+				OldType:   NoneDataType,
+				NewType:   newType,
+				Status:    history.CreatedVersionStatus,
+			})
+		}
+	}
+
+	sort.Slice(diff.Attributes, func(i, j int) bool {
+		return diff.Attributes[i].Attribute < diff.Attributes[j].Attribute
+	})
+
+	return diff, oldExist != newExist || len(diff.Attributes) > 0
+}
+
+func versionStatus(oldExist bool, newExist bool) history.VersionStatus {
+	switch {
+	case !oldExist:
+		return history.CreatedVersionStatus
+	case !newExist:
+		return history.DeletedVersionStatus
+	default:
+		return history.UpdatedVersionStatus
+	}
+}
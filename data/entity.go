@@ -6,6 +6,7 @@ import (
 
 	"tstore/history"
 	"tstore/idgen"
+	"tstore/reliable"
 	"tstore/storage"
 )
 
@@ -20,6 +21,8 @@ type EntityInput struct {
 	SchemaName                 string                 `json:"schema_name"`
 	AttributesToCreateOrUpdate map[string]interface{} `json:"attributes_to_create_or_update"`
 	AttributesToDelete         []string               `json:"attributes_to_delete"`
+	// RestoreCommitID is the commit a RestoreEntityMutation restores the entity to
+	RestoreCommitID uint64 `json:"restore_commit_id"`
 }
 
 // TODO: handle schema name change
@@ -70,7 +73,29 @@ func (e EntityValueHistory) AddVersion(commitID uint64, mutation Mutation) (bool
 	// TODO: return true when all AddVersion for a given mutation returns true
 	var updated bool
 	switch mutation.Type {
-	case CreateEntityMutation:
+	case CreateEntityMutation, RestoreEntityMutation:
+		// an entity deleted before keeps its attributes underneath, which are replaced by the new ones
+		currAttributes, _, err := e.attributesHistory.ListAllLatestValuesAt(commitID)
+		if err != nil {
+			log.Println(err)
+			return false, err
+		}
+
+		for attribute := range currAttributes {
+			if _, ok := mutation.EntityInput.AttributesToCreateOrUpdate[attribute]; ok {
+				continue
+			}
+
+			attributeUpdated, err := e.attributesHistory.AddVersion(
+				commitID, attribute, history.DeletedVersionStatus, NoneDataType)
+			if err != nil {
+				log.Println(err)
+				return false, err
+			}
+
+			updated = updated || attributeUpdated
+		}
+
 		idUpdated, err := e.idHistory.AddVersion(commitID, history.CreatedVersionStatus, mutation.EntityInput.EntityID)
 		if err != nil {
 			log.Println(err)
@@ -89,7 +114,12 @@ func (e EntityValueHistory) AddVersion(commitID uint64, mutation Mutation) (bool
 		updated = updated || schemaNameUpdated
 
 		for attribute, dataType := range mutation.EntityInput.AttributesToCreateOrUpdate {
-			attributeUpdated, err := e.attributesHistory.AddVersion(commitID, attribute, history.CreatedVersionStatus, dataType)
+			versionStatus := history.CreatedVersionStatus
+			if _, ok := currAttributes[attribute]; ok {
+				versionStatus = history.UpdatedVersionStatus
+			}
+
+			attributeUpdated, err := e.attributesHistory.AddVersion(commitID, attribute, versionStatus, dataType)
 			if err != nil {
 				log.Println(err)
 				return false, err
@@ -189,13 +219,35 @@ func (e EntityValueHistory) RemoveVersion(commitID uint64) (bool, error) {
 	return histRemoved || schemaNameRemoved || attributesRemoved, nil
 }
 
-func newEntityValueHistory(storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap) (EntityValueHistory, error) {
+func (e EntityValueHistory) Prune(baseCommitID uint64) error {
+	_, err := e.idHistory.Prune(baseCommitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	_, err = e.schemaNameHistory.Prune(baseCommitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return e.attributesHistory.Prune(baseCommitID)
+}
+
+func newEntityValueHistory(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+) (EntityValueHistory, error) {
 	idHistory, err := history.New[uint64, uint64, uint64](
 		path.Join(storagePath, "idHistory"),
 		refGen,
 		rawMap,
+		format,
 		func(storagePath string) (history.ValueHistory[uint64, uint64, uint64], error) {
-			return history.NewSingleValueHistory[uint64, uint64](storagePath, refGen, rawMap)
+			return history.NewSingleValueHistory[uint64, uint64](storagePath, refGen, rawMap, format)
 		})
 	if err != nil {
 		log.Println(err)
@@ -206,8 +258,9 @@ func newEntityValueHistory(storagePath string, refGen *idgen.IDGen, rawMap stora
 		path.Join(storagePath, "schemaNameHistory"),
 		refGen,
 		rawMap,
+		format,
 		func(storagePath string) (history.ValueHistory[uint64, string, string], error) {
-			return history.NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap)
+			return history.NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap, format)
 		})
 	if err != nil {
 		log.Println(err)
@@ -218,8 +271,9 @@ func newEntityValueHistory(storagePath string, refGen *idgen.IDGen, rawMap stora
 		path.Join(storagePath, "attributesHistory"),
 		refGen,
 		rawMap,
+		format,
 		func(valueStoragePath string) (history.ValueHistory[uint64, interface{}, interface{}], error) {
-			return history.NewSingleValueHistory[uint64, interface{}](valueStoragePath, refGen, rawMap)
+			return history.NewSingleValueHistory[uint64, interface{}](valueStoragePath, refGen, rawMap, format)
 		})
 	if err != nil {
 		log.Println(err)
@@ -0,0 +1,488 @@
+package data
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"tstore/history"
+)
+
+// EntityConflict is an entity attribute changed in different ways on main & on the branch since the merge base.
+// The statuses tell how each side changed it since the merge base.
+type EntityConflict struct {
+	EntityID   uint64 `json:"entity_id"`
+	SchemaName string `json:"schema_name"`
+	// Attribute is empty when one side deleted the entity while the other side changed it
+	Attribute    string                `json:"attribute"`
+	BaseValue    interface{}           `json:"base_value"`
+	MainValue    interface{}           `json:"main_value"`
+	BranchValue  interface{}           `json:"branch_value"`
+	MainStatus   history.VersionStatus `json:"main_status"`
+	BranchStatus history.VersionStatus `json:"branch_status"`
+}
+
+// SchemaConflict is a schema attribute changed in different ways on main & on the branch since the merge base.
+// The type is NoneDataType where the attribute is absent.
+type SchemaConflict struct {
+	SchemaName string `json:"schema_name"`
+	// Attribute is empty when one side deleted the schema while the other side changed it
+	Attribute    string                `json:"attribute"`
+	BaseType     Type                  `json:"base_type"`
+	MainType     Type                  `json:"main_type"`
+	BranchType   Type                  `json:"branch_type"`
+	MainStatus   history.VersionStatus `json:"main_status"`
+	BranchStatus history.VersionStatus `json:"branch_status"`
+}
+
+// MergePlan is what merging a branch does to main,
+// the mutations are only applicable when there is no conflict.
+type MergePlan struct {
+	Mutations       map[string][]Mutation `json:"mutations"` // key: schema name, value: mutation
+	EntityConflicts []EntityConflict      `json:"entity_conflicts"`
+	SchemaConflicts []SchemaConflict      `json:"schema_conflicts"`
+}
+
+func (m MergePlan) HasConflicts() bool {
+	return len(m.EntityConflicts) > 0 || len(m.SchemaConflicts) > 0
+}
+
+// MergeResult tells either the commit made on main by the merge or why the branch can't be merged.
+type MergeResult struct {
+	// CommitID is 0 when nothing is committed
+	CommitID        uint64           `json:"commit_id"`
+	EntityConflicts []EntityConflict `json:"entity_conflicts"`
+	SchemaConflicts []SchemaConflict `json:"schema_conflicts"`
+}
+
+func (m MergeResult) HasConflicts() bool {
+	return len(m.EntityConflicts) > 0 || len(m.SchemaConflicts) > 0
+}
+
+// Fork copies the schemas & the entities at the commit into the empty target as a single commit with the same ID,
+// so the target carries on from the commit while keeping the entity IDs.
+func (w WithVersion) Fork(commitID uint64, target *WithVersion) error {
+	commit, found, err := w.FindCommit(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("commit not found: commitID=%v", commitID)
+	}
+
+	schemas, _, err := w.SchemaHistories.ListAllLatestValuesAt(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	for schemaName, schema := range schemas {
+		_, err = target.SchemaHistories.AddVersion(commitID, schemaName, history.CreatedVersionStatus, Mutation{
+			Type: CreateSchemaMutation,
+			SchemaInput: SchemaInput{
+				Name:                       schemaName,
+				AttributesToCreateOrUpdate: schema.Attributes,
+			},
+		})
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	entities, err := w.EntitiesAt(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	for entityID, entity := range entities {
+		_, err = target.AddEntityVersion(commitID, entityID, history.CreatedVersionStatus, Mutation{
+			Type: CreateEntityMutation,
+			EntityInput: EntityInput{
+				EntityID:                   entityID,
+				SchemaName:                 entity.SchemaName,
+				AttributesToCreateOrUpdate: entity.Attributes,
+			},
+		})
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	return target.AppendCommit(commit)
+}
+
+// PlanMerge plans a three-way merge of the changes made on the branch from baseCommitID to branchCommitID
+// into the data at commitID.
+//
+// An attribute changed only on the branch is taken from the branch, while an attribute changed on both sides
+// is a conflict unless both sides agree on its value.
+func (w WithVersion) PlanMerge(
+	branch *WithVersion,
+	baseCommitID uint64,
+	branchCommitID uint64,
+	commitID uint64,
+) (MergePlan, error) {
+	plan := newMergePlan()
+	if baseCommitID == branchCommitID {
+		return plan.build(), nil
+	}
+
+	schemaDiffs, err := branch.diffSchemas(baseCommitID, branchCommitID)
+	if err != nil {
+		log.Println(err)
+		return MergePlan{}, err
+	}
+
+	for _, schemaDiff := range schemaDiffs {
+		err = w.planSchemaMerge(&plan, commitID, schemaDiff)
+		if err != nil {
+			log.Println(err)
+			return MergePlan{}, err
+		}
+	}
+
+	branchSchemas, _, err := branch.SchemaHistories.ListAllLatestValuesAt(branchCommitID)
+	if err != nil {
+		log.Println(err)
+		return MergePlan{}, err
+	}
+
+	entityDiffs, err := branch.diffEntities(baseCommitID, branchCommitID, func(entity Entity) bool {
+		return true
+	})
+	if err != nil {
+		log.Println(err)
+		return MergePlan{}, err
+	}
+
+	for _, entityDiff := range entityDiffs {
+		err = w.planEntityMerge(&plan, commitID, entityDiff, branchSchemas[entityDiff.SchemaName])
+		if err != nil {
+			log.Println(err)
+			return MergePlan{}, err
+		}
+	}
+
+	return plan.build(), nil
+}
+
+func (w WithVersion) planSchemaMerge(plan *mergePlan, commitID uint64, diff SchemaDiff) error {
+	schema, exist, err := w.SchemaHistories.FindLatestValueAt(commitID, diff.SchemaName)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	switch {
+	case diff.Status == history.CreatedVersionStatus && !exist:
+		attributes := make(map[string]Type)
+		for _, attributeDiff := range diff.Attributes {
+			attributes[attributeDiff.Attribute] = attributeDiff.NewType
+		}
+
+		plan.addFirst(diff.SchemaName, Mutation{
+			Type: CreateSchemaMutation,
+			SchemaInput: SchemaInput{
+				Name:                       diff.SchemaName,
+				AttributesToCreateOrUpdate: attributes,
+			},
+		})
+		return nil
+	case diff.Status == history.DeletedVersionStatus:
+		if !exist {
+			return nil
+		}
+
+		changed := len(schema.Attributes) != len(diff.Attributes)
+		for _, attributeDiff := range diff.Attributes {
+			dataType, typeExist := schema.Attributes[attributeDiff.Attribute]
+			changed = changed || !typeExist || dataType != attributeDiff.OldType
+		}
+
+		if changed {
+			plan.schemaConflicts = append(plan.schemaConflicts, SchemaConflict{
+				SchemaName:   diff.SchemaName,
+				MainStatus:   history.UpdatedVersionStatus,
+				BranchStatus: history.DeletedVersionStatus,
+			})
+			return nil
+		}
+
+		plan.addLast(diff.SchemaName, Mutation{
+			Type:        DeleteSchemaMutation,
+			SchemaInput: SchemaInput{Name: diff.SchemaName},
+		})
+		return nil
+	case diff.Status == history.UpdatedVersionStatus && !exist:
+		plan.schemaConflicts = append(plan.schemaConflicts, SchemaConflict{
+			SchemaName:   diff.SchemaName,
+			MainStatus:   history.DeletedVersionStatus,
+			BranchStatus: history.UpdatedVersionStatus,
+		})
+		return nil
+	}
+
+	attributesToCreate := make(map[string]Type)
+	attributesToChange := make([]string, 0)
+	attributesToDelete := make([]string, 0)
+	for _, attributeDiff := range diff.Attributes {
+		mainType, mainExist := schema.Attributes[attributeDiff.Attribute]
+		if !mainExist {
+			mainType = NoneDataType
+		}
+
+		if mainType == attributeDiff.NewType {
+			continue
+		}
+
+		if mainType != attributeDiff.OldType {
+			plan.schemaConflicts = append(plan.schemaConflicts, SchemaConflict{
+				SchemaName:   diff.SchemaName,
+				Attribute:    attributeDiff.Attribute,
+				BaseType:     attributeDiff.OldType,
+				MainType:     mainType,
+				BranchType:   attributeDiff.NewType,
+				MainStatus:   versionStatus(attributeDiff.OldType != NoneDataType, mainExist),
+				BranchStatus: attributeDiff.Status,
+			})
+			continue
+		}
+
+		switch {
+		case attributeDiff.NewType == NoneDataType:
+			attributesToDelete = append(attributesToDelete, attributeDiff.Attribute)
+		case mainExist:
+			// the attribute is deleted before created with the new type
+			attributesToChange = append(attributesToChange, attributeDiff.Attribute)
+			attributesToCreate[attributeDiff.Attribute] = attributeDiff.NewType
+		default:
+			attributesToCreate[attributeDiff.Attribute] = attributeDiff.NewType
+		}
+	}
+
+	if len(attributesToChange) > 0 {
+		plan.addFirst(diff.SchemaName, Mutation{
+			Type: DeleteSchemaAttributesMutation,
+			SchemaInput: SchemaInput{
+				Name:               diff.SchemaName,
+				AttributesToDelete: attributesToChange,
+			},
+		})
+	}
+
+	if len(attributesToCreate) > 0 {
+		plan.addFirst(diff.SchemaName, Mutation{
+			Type: CreateSchemaAttributesMutation,
+			SchemaInput: SchemaInput{
+				Name:                       diff.SchemaName,
+				AttributesToCreateOrUpdate: attributesToCreate,
+			},
+		})
+	}
+
+	// the entities drop the attributes on their own before the attributes leave the schema
+	if len(attributesToDelete) > 0 {
+		plan.addLast(diff.SchemaName, Mutation{
+			Type: DeleteSchemaAttributesMutation,
+			SchemaInput: SchemaInput{
+				Name:               diff.SchemaName,
+				AttributesToDelete: attributesToDelete,
+			},
+		})
+	}
+
+	return nil
+}
+
+// planEntityMerge converts the values taken from the branch with the schema on the branch.
+func (w WithVersion) planEntityMerge(plan *mergePlan, commitID uint64, diff EntityDiff, branchSchema Schema) error {
+	entity, exist, err := w.EntityHistories.FindLatestValueAt(commitID, diff.EntityID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	switch {
+	case diff.Status == history.CreatedVersionStatus && !exist:
+		attributes := make(map[string]interface{})
+		for _, attributeDiff := range diff.Attributes {
+			attributes[attributeDiff.Attribute] = ConvertValue(
+				branchSchema.Attributes[attributeDiff.Attribute], attributeDiff.NewValue)
+		}
+
+		plan.add(diff.SchemaName, Mutation{
+			Type: CreateEntityMutation,
+			EntityInput: EntityInput{
+				EntityID:                   diff.EntityID,
+				SchemaName:                 diff.SchemaName,
+				AttributesToCreateOrUpdate: attributes,
+			},
+		})
+		return nil
+	case diff.Status == history.DeletedVersionStatus:
+		if !exist {
+			return nil
+		}
+
+		changed := len(entity.Attributes) != len(diff.Attributes)
+		for _, attributeDiff := range diff.Attributes {
+			value, valueExist := entity.Attributes[attributeDiff.Attribute]
+			changed = changed || !valueExist || !reflect.DeepEqual(value, attributeDiff.OldValue)
+		}
+
+		if changed {
+			plan.entityConflicts = append(plan.entityConflicts, EntityConflict{
+				EntityID:     diff.EntityID,
+				SchemaName:   diff.SchemaName,
+				MainStatus:   history.UpdatedVersionStatus,
+				BranchStatus: history.DeletedVersionStatus,
+			})
+			return nil
+		}
+
+		plan.add(diff.SchemaName, Mutation{
+			Type:        DeleteEntityMutation,
+			EntityInput: EntityInput{EntityID: diff.EntityID, SchemaName: diff.SchemaName},
+		})
+		return nil
+	case diff.Status == history.UpdatedVersionStatus && !exist:
+		plan.entityConflicts = append(plan.entityConflicts, EntityConflict{
+			EntityID:     diff.EntityID,
+			SchemaName:   diff.SchemaName,
+			MainStatus:   history.DeletedVersionStatus,
+			BranchStatus: history.UpdatedVersionStatus,
+		})
+		return nil
+	}
+
+	attributesToCreate := make(map[string]interface{})
+	attributesToUpdate := make(map[string]interface{})
+	attributesToDelete := make([]string, 0)
+	for _, attributeDiff := range diff.Attributes {
+		baseExist := attributeDiff.Status != history.CreatedVersionStatus
+		branchExist := attributeDiff.Status != history.DeletedVersionStatus
+		value, mainExist := entity.Attributes[attributeDiff.Attribute]
+		if sameValue(value, mainExist, attributeDiff.NewValue, branchExist) {
+			continue
+		}
+
+		if !sameValue(value, mainExist, attributeDiff.OldValue, baseExist) {
+			plan.entityConflicts = append(plan.entityConflicts, EntityConflict{
+				EntityID:     diff.EntityID,
+				SchemaName:   diff.SchemaName,
+				Attribute:    attributeDiff.Attribute,
+				BaseValue:    attributeDiff.OldValue,
+				MainValue:    value,
+				BranchValue:  attributeDiff.NewValue,
+				MainStatus:   versionStatus(baseExist, mainExist),
+				BranchStatus: attributeDiff.Status,
+			})
+			continue
+		}
+
+		newValue := ConvertValue(branchSchema.Attributes[attributeDiff.Attribute], attributeDiff.NewValue)
+		switch {
+		case !branchExist:
+			attributesToDelete = append(attributesToDelete, attributeDiff.Attribute)
+		case !mainExist:
+			attributesToCreate[attributeDiff.Attribute] = newValue
+		default:
+			attributesToUpdate[attributeDiff.Attribute] = newValue
+		}
+	}
+
+	if len(attributesToCreate) > 0 {
+		plan.add(diff.SchemaName, Mutation{
+			Type: CreateEntityAttributesMutation,
+			EntityInput: EntityInput{
+				EntityID:                   diff.EntityID,
+				SchemaName:                 diff.SchemaName,
+				AttributesToCreateOrUpdate: attributesToCreate,
+			},
+		})
+	}
+
+	if len(attributesToUpdate) > 0 {
+		plan.add(diff.SchemaName, Mutation{
+			Type: UpdateEntityAttributesMutation,
+			EntityInput: EntityInput{
+				EntityID:                   diff.EntityID,
+				SchemaName:                 diff.SchemaName,
+				AttributesToCreateOrUpdate: attributesToUpdate,
+			},
+		})
+	}
+
+	if len(attributesToDelete) > 0 {
+		plan.add(diff.SchemaName, Mutation{
+			Type: DeleteEntityAttributesMutation,
+			EntityInput: EntityInput{
+				EntityID:           diff.EntityID,
+				SchemaName:         diff.SchemaName,
+				AttributesToDelete: attributesToDelete,
+			},
+		})
+	}
+
+	return nil
+}
+
+// mergePlan collects the mutations of each schema in the order they apply:
+// the schema changes come before the entity changes, except for deleting the schema & its attributes.
+type mergePlan struct {
+	first           map[string][]Mutation
+	mutations       map[string][]Mutation
+	last            map[string][]Mutation
+	entityConflicts []EntityConflict
+	schemaConflicts []SchemaConflict
+}
+
+func (m *mergePlan) addFirst(schemaName string, mutation Mutation) {
+	m.first[schemaName] = append(m.first[schemaName], mutation)
+}
+
+func (m *mergePlan) add(schemaName string, mutation Mutation) {
+	m.mutations[schemaName] = append(m.mutations[schemaName], mutation)
+}
+
+func (m *mergePlan) addLast(schemaName string, mutation Mutation) {
+	m.last[schemaName] = append(m.last[schemaName], mutation)
+}
+
+func (m mergePlan) build() MergePlan {
+	mutations := make(map[string][]Mutation)
+	for _, group := range []map[string][]Mutation{m.first, m.mutations, m.last} {
+		for schemaName, schemaMutations := range group {
+			mutations[schemaName] = append(mutations[schemaName], schemaMutations...)
+		}
+	}
+
+	return MergePlan{
+		Mutations:       mutations,
+		EntityConflicts: m.entityConflicts,
+		SchemaConflicts: m.schemaConflicts,
+	}
+}
+
+func newMergePlan() mergePlan {
+	return mergePlan{
+		first:           make(map[string][]Mutation),
+		mutations:       make(map[string][]Mutation),
+		last:            make(map[string][]Mutation),
+		entityConflicts: make([]EntityConflict, 0),
+		schemaConflicts: make([]SchemaConflict, 0),
+	}
+}
+
+func sameValue(value interface{}, exist bool, otherValue interface{}, otherExist bool) bool {
+	if !exist || !otherExist {
+		return exist == otherExist
+	}
+
+	return reflect.DeepEqual(value, otherValue)
+}
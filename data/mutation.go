@@ -13,6 +13,7 @@ const (
 	CreateEntityAttributesMutation MutationType = "createEntityAttributes"
 	DeleteEntityAttributesMutation MutationType = "deleteEntityAttributes"
 	UpdateEntityAttributesMutation MutationType = "updateEntityAttributes"
+	RestoreEntityMutation          MutationType = "restoreEntity"
 )
 
 type Mutation struct {
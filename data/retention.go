@@ -0,0 +1,155 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"time"
+
+	"tstore/reliable"
+	"tstore/storage"
+)
+
+// RetentionPolicy limits how far back the versions are kept, the zero value keeps everything.
+// A commit is kept when any of the limits keeps it, and the latest commit is always kept.
+type RetentionPolicy struct {
+	// KeepCommits keeps the latest KeepCommits commits, 0 disables the limit
+	KeepCommits int `json:"keep_commits"`
+	// KeepDuration keeps the commits made within KeepDuration, 0 disables the limit
+	KeepDuration time.Duration `json:"keep_duration"`
+}
+
+func (r RetentionPolicy) KeepAll() bool {
+	return r.KeepCommits <= 0 && r.KeepDuration <= 0
+}
+
+// horizon returns the oldest commit kept by the policy, false when every commit is kept
+func (r RetentionPolicy) horizon(commits reliable.List[Commit], now time.Time) (uint64, bool, error) {
+	if r.KeepAll() {
+		return 0, false, nil
+	}
+
+	iterator := commits.ReverseIterator()
+	defer iterator.Close()
+
+	var horizon uint64
+	count := 0
+	for iterator.Next() {
+		commit := iterator.Item()
+		keptByCount := r.KeepCommits > 0 && count < r.KeepCommits
+		keptByAge := r.KeepDuration > 0 && !commit.CommittedAt.Before(now.Add(-r.KeepDuration))
+		if count > 0 && !keptByCount && !keptByAge {
+			return horizon, true, nil
+		}
+
+		horizon = commit.CommittedTransactionID
+		count++
+	}
+
+	return 0, false, iterator.Err()
+}
+
+type CommitPruned string
+
+func (c CommitPruned) Error() string {
+	return fmt.Sprintf("commit pruned: %v", (string)(c))
+}
+
+var _ error = (*CommitPruned)(nil)
+
+// retention persists the retention policy of a database and how far its versions are pruned.
+//
+// The horizon is recorded before pruning starts, so the commits being pruned are rejected right away,
+// while the pruned horizon is recorded once pruning completes, so an interrupted pruning is resumed.
+type retention struct {
+	storagePath string
+	rawMap      storage.RawMap
+}
+
+func (r retention) policy() (RetentionPolicy, bool, error) {
+	var policy RetentionPolicy
+	found, err := r.get(r.policyPath(), &policy)
+	return policy, found, err
+}
+
+func (r retention) setPolicy(policy RetentionPolicy) error {
+	return r.set(r.policyPath(), policy)
+}
+
+// horizon returns the oldest commit still readable
+func (r retention) horizon() (uint64, error) {
+	var horizon uint64
+	_, err := r.get(r.horizonPath(), &horizon)
+	return horizon, err
+}
+
+func (r retention) setHorizon(horizon uint64) error {
+	return r.set(r.horizonPath(), horizon)
+}
+
+func (r retention) prunedHorizon() (uint64, error) {
+	var horizon uint64
+	_, err := r.get(r.prunedHorizonPath(), &horizon)
+	return horizon, err
+}
+
+func (r retention) setPrunedHorizon(horizon uint64) error {
+	return r.set(r.prunedHorizonPath(), horizon)
+}
+
+func (r retention) checkRetained(commitID uint64) error {
+	horizon, err := r.horizon()
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if commitID < horizon {
+		return CommitPruned(fmt.Sprintf("commitID=%v, oldestRetainedCommitID=%v", commitID, horizon))
+	}
+
+	return nil
+}
+
+func (r retention) get(key string, value interface{}) (bool, error) {
+	contain, err := r.rawMap.Contain(key)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	if !contain {
+		return false, nil
+	}
+
+	buf, err := r.rawMap.Get(key)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return true, json.Unmarshal(buf, value)
+}
+
+func (r retention) set(key string, value interface{}) error {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return r.rawMap.Set(key, buf)
+}
+
+func (r retention) policyPath() string {
+	return path.Join(r.storagePath, "policy")
+}
+
+func (r retention) horizonPath() string {
+	return path.Join(r.storagePath, "horizon")
+}
+
+func (r retention) prunedHorizonPath() string {
+	return path.Join(r.storagePath, "prunedHorizon")
+}
@@ -6,6 +6,7 @@ import (
 
 	"tstore/history"
 	"tstore/idgen"
+	"tstore/reliable"
 	"tstore/storage"
 )
 
@@ -146,13 +147,29 @@ func (s SchemaValueHistory) RemoveVersion(commitID uint64) (bool, error) {
 	return nameRemoved || attributesRemoved, nil
 }
 
-func newSchemaValueHistory(storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap) (SchemaValueHistory, error) {
+func (s SchemaValueHistory) Prune(baseCommitID uint64) error {
+	_, err := s.nameHistory.Prune(baseCommitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return s.attributesHistory.Prune(baseCommitID)
+}
+
+func newSchemaValueHistory(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+) (SchemaValueHistory, error) {
 	nameHistory, err := history.New[uint64, string, string](
 		path.Join(storagePath, "nameHistory"),
 		refGen,
 		rawMap,
+		format,
 		func(storagePath string) (history.ValueHistory[uint64, string, string], error) {
-			return history.NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap)
+			return history.NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap, format)
 		})
 	if err != nil {
 		log.Println(err)
@@ -163,8 +180,9 @@ func newSchemaValueHistory(storagePath string, refGen *idgen.IDGen, rawMap stora
 		path.Join(storagePath, "attributesHistory"),
 		refGen,
 		rawMap,
+		format,
 		func(storagePath string) (history.ValueHistory[uint64, Type, Type], error) {
-			return history.NewSingleValueHistory[uint64, Type](storagePath, refGen, rawMap)
+			return history.NewSingleValueHistory[uint64, Type](storagePath, refGen, rawMap, format)
 		})
 	if err != nil {
 		log.Println(err)
@@ -0,0 +1,434 @@
+package data
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"path"
+	"sort"
+	"sync"
+
+	"tstore/history"
+	"tstore/idgen"
+	"tstore/reliable"
+	"tstore/storage"
+)
+
+// snapshots materializes the entities at every interval commits, so reading the entities at a commit only
+// replays the entities changed since the nearest snapshot instead of every entity history.
+//
+// Every change of an entity is recorded before the change is added to the entity history,
+// so a recorded change without a version only causes an extra lookup.
+type snapshots struct {
+	storagePath     string
+	refGen          *idgen.IDGen
+	rawMap          storage.RawMap
+	format          reliable.Format
+	interval        int
+	entityHistories history.KeyValue[uint64, uint64, Entity, Mutation]
+	// index maps the commit of each snapshot to its number of entities
+	index reliable.BTree[uint64, int]
+	// changes maps changeKey(commitID, entityID) to the entity changed by the commit
+	changes reliable.BTree[string, uint64]
+	// mut guards the trees, mutations of different schemas are committed in parallel
+	mut sync.RWMutex
+}
+
+func (s *snapshots) recordChange(commitID uint64, entityID uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.changes.Put(changeKey(commitID, entityID), entityID)
+}
+
+// entitiesAt loads the nearest snapshot at or before the commit and applies the changes made after it
+func (s *snapshots) entitiesAt(commitID uint64) (map[uint64]Entity, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	return s.loadEntitiesAt(commitID)
+}
+
+// loadEntitiesAt is entitiesAt for a caller holding mut
+func (s *snapshots) loadEntitiesAt(commitID uint64) (map[uint64]Entity, error) {
+	snapshotIterator := s.index.DescendFrom(commitID)
+	defer snapshotIterator.Close()
+
+	if !snapshotIterator.Next() {
+		if snapshotIterator.Err() != nil {
+			log.Println(snapshotIterator.Err())
+			return nil, snapshotIterator.Err()
+		}
+
+		// commits before the 1st snapshot of a database created before snapshots existed
+		entities, _, err := s.entityHistories.ListAllLatestValuesAt(commitID)
+		return entities, err
+	}
+
+	snapshotCommitID := snapshotIterator.Key()
+	entities := make(map[uint64]Entity, snapshotIterator.Value())
+	snapshot, err := reliable.NewBTree[uint64, Entity](s.snapshotPath(snapshotCommitID), s.refGen, s.rawMap, s.format)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	entityIterator := snapshot.Ascend()
+	defer entityIterator.Close()
+
+	for entityIterator.Next() {
+		entities[entityIterator.Key()] = entityIterator.Value()
+	}
+
+	if entityIterator.Err() != nil {
+		log.Println(entityIterator.Err())
+		return nil, entityIterator.Err()
+	}
+
+	if snapshotCommitID == commitID {
+		return entities, nil
+	}
+
+	changedEntityIDs, err := s.changedEntityIDs(snapshotCommitID+1, commitID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	for _, entityID := range changedEntityIDs {
+		entity, exist, err := s.entityHistories.FindLatestValueAt(commitID, entityID)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if exist {
+			entities[entityID] = entity
+		} else {
+			delete(entities, entityID)
+		}
+	}
+
+	return entities, nil
+}
+
+// take materializes the entities at the commit into the batch recording the commit when the commit is the interval-th one
+func (s *snapshots) take(batch storage.RawMap, commitID uint64, commitCount int) error {
+	if s.interval <= 0 || commitCount%s.interval != 0 {
+		return nil
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	entities, err := s.loadEntitiesAt(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return s.writeSnapshot(batch, commitID, entities)
+}
+
+// invalidate removes the snapshots & the changes at or after the commit when the commit is rolled back
+func (s *snapshots) invalidate(commitID uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	snapshotIterator := s.index.DescendFrom(math.MaxUint64)
+	defer snapshotIterator.Close()
+
+	snapshotCommitIDs := make([]uint64, 0)
+	for snapshotIterator.Next() && snapshotIterator.Key() >= commitID {
+		snapshotCommitIDs = append(snapshotCommitIDs, snapshotIterator.Key())
+	}
+
+	if snapshotIterator.Err() != nil {
+		log.Println(snapshotIterator.Err())
+		return snapshotIterator.Err()
+	}
+
+	changeIterator := s.changes.AscendFrom(changeKey(commitID, 0))
+	defer changeIterator.Close()
+
+	changeKeys := make([]string, 0)
+	for changeIterator.Next() && changeIterator.Key() <= changeKey(commitID, math.MaxUint64) {
+		changeKeys = append(changeKeys, changeIterator.Key())
+	}
+
+	if changeIterator.Err() != nil {
+		log.Println(changeIterator.Err())
+		return changeIterator.Err()
+	}
+
+	return s.remove(snapshotCommitIDs, changeKeys)
+}
+
+// prune removes the snapshots before the latest snapshot at or before horizon & the changes they include
+func (s *snapshots) prune(horizon uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	baseIterator := s.index.DescendFrom(horizon)
+	defer baseIterator.Close()
+
+	if !baseIterator.Next() {
+		if baseIterator.Err() != nil {
+			log.Println(baseIterator.Err())
+		}
+
+		return baseIterator.Err()
+	}
+
+	baseCommitID := baseIterator.Key()
+	snapshotIterator := s.index.Ascend()
+	defer snapshotIterator.Close()
+
+	snapshotCommitIDs := make([]uint64, 0)
+	for snapshotIterator.Next() && snapshotIterator.Key() < baseCommitID {
+		snapshotCommitIDs = append(snapshotCommitIDs, snapshotIterator.Key())
+	}
+
+	if snapshotIterator.Err() != nil {
+		log.Println(snapshotIterator.Err())
+		return snapshotIterator.Err()
+	}
+
+	changeIterator := s.changes.Ascend()
+	defer changeIterator.Close()
+
+	changeKeys := make([]string, 0)
+	for changeIterator.Next() && changeIterator.Key() <= changeKey(baseCommitID, math.MaxUint64) {
+		changeKeys = append(changeKeys, changeIterator.Key())
+	}
+
+	if changeIterator.Err() != nil {
+		log.Println(changeIterator.Err())
+		return changeIterator.Err()
+	}
+
+	return s.remove(snapshotCommitIDs, changeKeys)
+}
+
+// remove deletes the snapshots & the changes in a single batch
+func (s *snapshots) remove(snapshotCommitIDs []uint64, changeKeys []string) error {
+	return storage.RunInBatch(s.rawMap, func(batch storage.RawMap) error {
+		index, err := reliable.NewBTree[uint64, int](s.indexPath(), s.refGen, batch, s.format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		for _, snapshotCommitID := range snapshotCommitIDs {
+			_, err = index.Delete(snapshotCommitID)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			err = batch.Delete(s.snapshotPath(snapshotCommitID))
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+
+		changes, err := reliable.NewBTree[string, uint64](s.changesPath(), s.refGen, batch, s.format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		for _, key := range changeKeys {
+			_, err = changes.Delete(key)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// changedEntityIDs returns the entities changed by the commits within [beginCommitID, endCommitID],
+// the caller holds mut
+func (s *snapshots) changedEntityIDs(beginCommitID uint64, endCommitID uint64) ([]uint64, error) {
+	iterator := s.changes.AscendFrom(changeKey(beginCommitID, 0))
+	defer iterator.Close()
+
+	endKey := changeKey(endCommitID, math.MaxUint64)
+	seen := make(map[uint64]bool)
+	entityIDs := make([]uint64, 0)
+	for iterator.Next() && iterator.Key() <= endKey {
+		entityID := iterator.Value()
+		if !seen[entityID] {
+			seen[entityID] = true
+			entityIDs = append(entityIDs, entityID)
+		}
+	}
+
+	return entityIDs, iterator.Err()
+}
+
+// changedEntityIDsAfter returns the entities changed by the commits within (beginCommitID, endCommitID],
+// false when the changes before the 1st snapshot were never recorded.
+func (s *snapshots) changedEntityIDsAfter(beginCommitID uint64, endCommitID uint64) ([]uint64, bool, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	snapshotIterator := s.index.Ascend()
+	defer snapshotIterator.Close()
+
+	if !snapshotIterator.Next() || snapshotIterator.Key() > beginCommitID {
+		if snapshotIterator.Err() != nil {
+			log.Println(snapshotIterator.Err())
+		}
+
+		return nil, false, snapshotIterator.Err()
+	}
+
+	entityIDs, err := s.changedEntityIDs(beginCommitID+1, endCommitID)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	return entityIDs, true, nil
+}
+
+func (s *snapshots) writeSnapshot(batch storage.RawMap, commitID uint64, entities map[uint64]Entity) error {
+	entries := make([]reliable.BTreeEntry[uint64, Entity], 0, len(entities))
+	for entityID, entity := range entities {
+		entries = append(entries, reliable.BTreeEntry[uint64, Entity]{Key: entityID, Value: entity})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	snapshotPath := s.snapshotPath(commitID)
+	err := batch.Delete(snapshotPath)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	snapshot, err := reliable.NewBTree[uint64, Entity](snapshotPath, s.refGen, batch, s.format)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	err = snapshot.BulkLoad(entries)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	index, err := reliable.NewBTree[uint64, int](s.indexPath(), s.refGen, batch, s.format)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return index.Put(commitID, len(entries))
+}
+
+func (s *snapshots) indexPath() string {
+	return path.Join(s.storagePath, "index")
+}
+
+func (s *snapshots) changesPath() string {
+	return path.Join(s.storagePath, "changes")
+}
+
+func (s *snapshots) snapshotPath(commitID uint64) string {
+	return path.Join(s.storagePath, "entities", fmt.Sprintf("%v", commitID))
+}
+
+// newSnapshots opens the snapshots of a database.
+// A database created before snapshots existed is snapshotted at its latest commit,
+// since the changes made before it were never recorded.
+func newSnapshots(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+	interval int,
+	entityHistories history.KeyValue[uint64, uint64, Entity, Mutation],
+	commits reliable.List[Commit],
+) (*snapshots, error) {
+	s := &snapshots{
+		storagePath:     storagePath,
+		refGen:          refGen,
+		rawMap:          rawMap,
+		format:          format,
+		interval:        interval,
+		entityHistories: entityHistories,
+	}
+
+	contain, err := rawMap.Contain(storagePath)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if !contain {
+		err = storage.RunInBatch(rawMap, func(batch storage.RawMap) error {
+			var latestCommitID uint64
+			entities := make(map[uint64]Entity)
+			commitCount, err := commits.Length()
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			if commitCount > 0 {
+				latestCommit, err := commits.Peek()
+				if err != nil {
+					log.Println(err)
+					return err
+				}
+
+				latestCommitID = latestCommit.CommittedTransactionID
+				entities, _, err = entityHistories.ListAllLatestValuesAt(latestCommitID)
+				if err != nil {
+					log.Println(err)
+					return err
+				}
+			}
+
+			_, err = reliable.NewBTree[string, uint64](s.changesPath(), refGen, batch, format)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			return s.writeSnapshot(batch, latestCommitID, entities)
+		})
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+	}
+
+	s.index, err = reliable.NewBTree[uint64, int](s.indexPath(), refGen, rawMap, format)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	s.changes, err = reliable.NewBTree[string, uint64](s.changesPath(), refGen, rawMap, format)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// changeKey orders the changes by commit then by entity
+func changeKey(commitID uint64, entityID uint64) string {
+	return fmt.Sprintf("%020d/%020d", commitID, entityID)
+}
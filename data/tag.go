@@ -0,0 +1,105 @@
+package data
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"tstore/reliable"
+)
+
+type Tag struct {
+	Name     string `json:"name"`
+	CommitID uint64 `json:"commit_id"`
+}
+
+// CommitRef selects a commit by its ID, or by the tag naming it when Tag is set.
+type CommitRef struct {
+	CommitID uint64 `json:"commit_id"`
+	Tag      string `json:"tag"`
+}
+
+func CommitIDRef(commitID uint64) CommitRef {
+	return CommitRef{CommitID: commitID}
+}
+
+func TagRef(name string) CommitRef {
+	return CommitRef{Tag: name}
+}
+
+type TagExists string
+
+func (t TagExists) Error() string {
+	return fmt.Sprintf("tag exists: %v", (string)(t))
+}
+
+var _ error = (*TagExists)(nil)
+
+type TagNotFound string
+
+func (t TagNotFound) Error() string {
+	return fmt.Sprintf("tag not found: %v", (string)(t))
+}
+
+var _ error = (*TagNotFound)(nil)
+
+// tags names commits, a tag can't be moved once created.
+type tags struct {
+	commitIDs reliable.Map[string, uint64]
+	// mut makes checking & creating a tag atomic
+	mut sync.Mutex
+}
+
+func (t *tags) create(name string, commitID uint64) error {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	contain, err := t.commitIDs.Contain(name)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if contain {
+		return TagExists(fmt.Sprintf("name=%v", name))
+	}
+
+	return t.commitIDs.Set(name, commitID)
+}
+
+func (t *tags) resolve(name string) (uint64, error) {
+	contain, err := t.commitIDs.Contain(name)
+	if err != nil {
+		log.Println(err)
+		return 0, err
+	}
+
+	if !contain {
+		return 0, TagNotFound(fmt.Sprintf("name=%v", name))
+	}
+
+	return t.commitIDs.Get(name)
+}
+
+func (t *tags) list() ([]Tag, error) {
+	names, err := t.commitIDs.Keys()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	sort.Strings(names)
+	tagList := make([]Tag, 0, len(names))
+	for _, name := range names {
+		commitID, err := t.commitIDs.Get(name)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		tagList = append(tagList, Tag{Name: name, CommitID: commitID})
+	}
+
+	return tagList, nil
+}
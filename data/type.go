@@ -34,3 +34,28 @@ func GetType(value interface{}) Type {
 		return StringDataType
 	}
 }
+
+// ConvertValue converts a value decoded without its type, such as a number decoded from JSON as float64,
+// back to the type of the attribute.
+func ConvertValue(dataType Type, value interface{}) interface{} {
+	switch currValue := value.(type) {
+	case float64:
+		switch dataType {
+		case IntDataType:
+			return int64(currValue)
+		case RuneDataType:
+			return rune(currValue)
+		}
+	case string:
+		if dataType != DatetimeDataType {
+			return value
+		}
+
+		datetime, err := time.Parse(time.RFC3339Nano, currValue)
+		if err == nil {
+			return datetime
+		}
+	}
+
+	return value
+}
@@ -1,24 +1,320 @@
 package data
 
 import (
+	"fmt"
+	"log"
 	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"tstore/history"
 	"tstore/idgen"
 	"tstore/reliable"
 	"tstore/storage"
+	"tstore/types"
 )
 
 // TODO: persist data
 
 type WithVersion struct {
-	commits         reliable.List[Commit]
+	storagePath string
+	refGen      *idgen.IDGen
+	rawMap      storage.RawMap
+	format      reliable.Format
+	commits     reliable.List[Commit]
+	// commitIndex finds the commits by their committed transaction ID
+	commitIndex reliable.BTree[uint64, Commit]
+	// commitTimeIndex finds the latest commit made at a time by the UnixNano of CommittedAt
+	commitTimeIndex reliable.BTree[int64, uint64]
+	snapshots       *snapshots
+	retention       retention
+	tags            *tags
 	SchemaHistories history.KeyValue[uint64, string, Schema, Mutation] `json:"schema_histories"`
 	EntityHistories history.KeyValue[uint64, uint64, Entity, Mutation] `json:"entity_histories"`
 }
 
+// AppendCommit records the commit with its snapshot & its indexes in one batch,
+// so the commit is either fully recorded or absent. The commits are recorded in the order of their times.
 func (w *WithVersion) AppendCommit(commit Commit) error {
-	return w.commits.Append(commit)
+	count, err := w.commits.Length()
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if count > 0 {
+		latest, err := w.commits.Peek()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		// the time index keeps one commit per time & later commits must have later times,
+		// so a commit made at the time of the previous one or before it after a clock step is moved right after it
+		if commit.CommittedAt.UnixNano() <= latest.CommittedAt.UnixNano() {
+			commit.CommittedAt = latest.CommittedAt.Add(time.Nanosecond)
+		}
+	}
+
+	return storage.RunInBatch(w.rawMap, func(batch storage.RawMap) error {
+		err := w.snapshots.take(batch, commit.CommittedTransactionID, count+1)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		commitIndex, err := reliable.NewBTree[uint64, Commit](w.commitIndexPath(), w.refGen, batch, w.format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = commitIndex.Put(commit.CommittedTransactionID, commit)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		commitTimeIndex, err := reliable.NewBTree[int64, uint64](w.commitTimeIndexPath(), w.refGen, batch, w.format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = commitTimeIndex.Put(commit.CommittedAt.UnixNano(), commit.CommittedTransactionID)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		commits, err := reliable.NewList[Commit](w.commitsPath(), w.refGen, batch, w.format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return commits.Append(commit)
+	})
+}
+
+// FindCommit returns the commit of the transaction, false when the transaction is not committed.
+func (w WithVersion) FindCommit(transactionID uint64) (Commit, bool, error) {
+	return w.commitIndex.Get(transactionID)
+}
+
+// IsCommitted tells whether the commit of the transaction is recorded.
+func (w WithVersion) IsCommitted(transactionID uint64) (bool, error) {
+	_, found, err := w.commitIndex.Get(transactionID)
+	return found, err
+}
+
+// CommitAt returns the latest commit made at or before the time, false when there is no such commit.
+func (w WithVersion) CommitAt(at time.Time) (Commit, bool, error) {
+	iterator := w.commitTimeIndex.DescendFrom(at.UnixNano())
+	defer iterator.Close()
+
+	if !iterator.Next() {
+		if iterator.Err() != nil {
+			log.Println(iterator.Err())
+		}
+
+		return Commit{}, false, iterator.Err()
+	}
+
+	return w.commitIndex.Get(iterator.Value())
+}
+
+// AddEntityVersion adds a version to the history of the entity & records the change for the snapshots.
+func (w *WithVersion) AddEntityVersion(
+	commitID uint64,
+	entityID uint64,
+	versionStatus history.VersionStatus,
+	mutation Mutation,
+) (bool, error) {
+	err := w.snapshots.recordChange(commitID, entityID)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return w.EntityHistories.AddVersion(commitID, entityID, versionStatus, mutation)
+}
+
+// RemoveVersion removes all the changes made by the commit.
+func (w *WithVersion) RemoveVersion(commitID uint64) error {
+	err := w.snapshots.invalidate(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	err = w.removeCommit(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	_, err = w.EntityHistories.RemoveVersion(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	_, err = w.SchemaHistories.RemoveVersion(commitID)
+	if err != nil {
+		log.Println(err)
+	}
+
+	return err
+}
+
+// removeCommit removes the commit from the indexes, the time index is left alone when a later commit
+// made at the same time replaced it.
+func (w *WithVersion) removeCommit(commitID uint64) error {
+	commit, found, err := w.commitIndex.Get(commitID)
+	if err != nil || !found {
+		return err
+	}
+
+	timeKey := commit.CommittedAt.UnixNano()
+	indexedCommitID, found, err := w.commitTimeIndex.Get(timeKey)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if found && indexedCommitID == commitID {
+		_, err = w.commitTimeIndex.Delete(timeKey)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	_, err = w.commitIndex.Delete(commitID)
+	return err
+}
+
+// EntitiesAt returns the entities present at the commit, keyed by entity ID.
+func (w WithVersion) EntitiesAt(commitID uint64) (map[uint64]Entity, error) {
+	err := w.CheckRetained(commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.snapshots.entitiesAt(commitID)
+}
+
+// CreateTag names a committed commit, a tag can't be moved once created.
+func (w WithVersion) CreateTag(name string, commitID uint64) error {
+	// tags are stored under their name
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid tag name: %q", name)
+	}
+
+	_, found, err := w.FindCommit(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("commit not found: commitID=%v", commitID)
+	}
+
+	return w.tags.create(name, commitID)
+}
+
+// ResolveTag returns the commit named by the tag, TagNotFound when the tag doesn't exist.
+func (w WithVersion) ResolveTag(name string) (uint64, error) {
+	return w.tags.resolve(name)
+}
+
+// ResolveCommitRef returns the ID of the commit selected by the ref.
+func (w WithVersion) ResolveCommitRef(ref CommitRef) (uint64, error) {
+	if ref.Tag == "" {
+		return ref.CommitID, nil
+	}
+
+	return w.tags.resolve(ref.Tag)
+}
+
+// ListTags returns the tags sorted by name.
+func (w WithVersion) ListTags() ([]Tag, error) {
+	return w.tags.list()
+}
+
+// CheckRetained returns CommitPruned when the versions at the commit were pruned.
+func (w WithVersion) CheckRetained(commitID uint64) error {
+	return w.retention.checkRetained(commitID)
+}
+
+// RetentionPolicy returns the policy set on the data, false when it is not set.
+func (w WithVersion) RetentionPolicy() (RetentionPolicy, bool, error) {
+	return w.retention.policy()
+}
+
+func (w *WithVersion) SetRetentionPolicy(policy RetentionPolicy) error {
+	return w.retention.setPolicy(policy)
+}
+
+// Prune collapses the versions older than the commits kept by the policy.
+// It must not run concurrently with commits.
+func (w *WithVersion) Prune(policy RetentionPolicy, now time.Time) error {
+	horizon, ok, err := policy.horizon(w.commits, now)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	currHorizon, err := w.retention.horizon()
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	// pruned versions can't be brought back when the policy is relaxed
+	if !ok || horizon < currHorizon {
+		horizon = currHorizon
+	}
+
+	prunedHorizon, err := w.retention.prunedHorizon()
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if horizon <= prunedHorizon {
+		return nil
+	}
+
+	err = w.retention.setHorizon(horizon)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	log.Printf("[WithVersion][Prune] horizon=%v\n", horizon)
+	err = w.EntityHistories.Prune(horizon)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	err = w.SchemaHistories.Prune(horizon)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	err = w.snapshots.prune(horizon)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return w.retention.setPrunedHorizon(horizon)
 }
 
 func (w WithVersion) CountCommits() (int, error) {
@@ -29,9 +325,16 @@ func (w WithVersion) GetLatestCommit() (Commit, error) {
 	return w.commits.Peek()
 }
 
-func NewWithVersion(storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap) (*WithVersion, error) {
-	commitsPath := path.Join(storagePath, "commits")
-	commits, err := reliable.NewList[Commit](commitsPath, refGen, rawMap)
+// NewWithVersion opens the versioned data, the entities are snapshotted every snapshotInterval commits
+// and 0 disables the snapshots.
+func NewWithVersion(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+	snapshotInterval int,
+) (*WithVersion, error) {
+	commits, err := reliable.NewList[Commit](path.Join(storagePath, "commits"), refGen, rawMap, format)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +343,9 @@ func NewWithVersion(storagePath string, refGen *idgen.IDGen, rawMap storage.RawM
 		path.Join(storagePath, "schemaHistories"),
 		refGen,
 		rawMap,
+		format,
 		func(storagePath string) (history.ValueHistory[uint64, Schema, Mutation], error) {
-			return newSchemaValueHistory(storagePath, refGen, rawMap)
+			return newSchemaValueHistory(storagePath, refGen, rawMap, format)
 		})
 	if err != nil {
 		return nil, err
@@ -51,16 +355,152 @@ func NewWithVersion(storagePath string, refGen *idgen.IDGen, rawMap storage.RawM
 		path.Join(storagePath, "entityHistories"),
 		refGen,
 		rawMap,
+		format,
 		func(storagePath string) (history.ValueHistory[uint64, Entity, Mutation], error) {
-			return newEntityValueHistory(storagePath, refGen, rawMap)
+			return newEntityValueHistory(storagePath, refGen, rawMap, format)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	commitIndex, err := openCommitIndex(
+		path.Join(storagePath, "commitIndex"),
+		refGen,
+		rawMap,
+		format,
+		commits,
+		func(commit Commit) reliable.BTreeEntry[uint64, Commit] {
+			return reliable.BTreeEntry[uint64, Commit]{Key: commit.CommittedTransactionID, Value: commit}
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	commitTimeIndex, err := openCommitIndex(
+		path.Join(storagePath, "commitTimeIndex"),
+		refGen,
+		rawMap,
+		format,
+		commits,
+		func(commit Commit) reliable.BTreeEntry[int64, uint64] {
+			return reliable.BTreeEntry[int64, uint64]{
+				Key:   commit.CommittedAt.UnixNano(),
+				Value: commit.CommittedTransactionID,
+			}
 		})
 	if err != nil {
 		return nil, err
 	}
 
+	snapshots, err := newSnapshots(
+		path.Join(storagePath, "snapshots"),
+		refGen,
+		rawMap,
+		format,
+		snapshotInterval,
+		entityHistories,
+		commits)
+	if err != nil {
+		return nil, err
+	}
+
+	tagCommitIDs, err := reliable.NewMap[string, uint64](path.Join(storagePath, "tags"), refGen, rawMap, format)
+	if err != nil {
+		return nil, err
+	}
+
 	return &WithVersion{
+		storagePath:     storagePath,
+		refGen:          refGen,
+		rawMap:          rawMap,
+		format:          format,
 		commits:         commits,
+		commitIndex:     commitIndex,
+		commitTimeIndex: commitTimeIndex,
+		snapshots:       snapshots,
+		retention: retention{
+			storagePath: path.Join(storagePath, "retention"),
+			rawMap:      rawMap,
+		},
+		tags:            &tags{commitIDs: tagCommitIDs},
 		SchemaHistories: schemaHistories,
 		EntityHistories: entityHistories,
 	}, nil
 }
+
+func (w WithVersion) commitsPath() string {
+	return path.Join(w.storagePath, "commits")
+}
+
+func (w WithVersion) commitIndexPath() string {
+	return path.Join(w.storagePath, "commitIndex")
+}
+
+func (w WithVersion) commitTimeIndexPath() string {
+	return path.Join(w.storagePath, "commitTimeIndex")
+}
+
+// openCommitIndex opens an index of the commits, entry maps each commit to its entry in the index.
+// Data written before the index existed is migrated by bulk loading the commits,
+// the later commit wins when 2 commits map to the same key.
+func openCommitIndex[Key types.Comparable, Value any](
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+	commits reliable.List[Commit],
+	entry func(commit Commit) reliable.BTreeEntry[Key, Value],
+) (reliable.BTree[Key, Value], error) {
+	contain, err := rawMap.Contain(storagePath)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[Key, Value]{}, err
+	}
+
+	if contain {
+		return reliable.NewBTree[Key, Value](storagePath, refGen, rawMap, format)
+	}
+
+	items, err := commits.Items()
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[Key, Value]{}, err
+	}
+
+	entries := make([]reliable.BTreeEntry[Key, Value], 0, len(items))
+	for _, commit := range items {
+		entries = append(entries, entry(commit))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	uniqueEntries := make([]reliable.BTreeEntry[Key, Value], 0, len(entries))
+	for _, entry := range entries {
+		last := len(uniqueEntries) - 1
+		if last >= 0 && uniqueEntries[last].Key == entry.Key {
+			uniqueEntries[last] = entry
+			continue
+		}
+
+		uniqueEntries = append(uniqueEntries, entry)
+	}
+
+	// the index is created & loaded in one batch, so a crash never leaves a partially migrated index
+	err = storage.RunInBatch(rawMap, func(batch storage.RawMap) error {
+		index, err := reliable.NewBTree[Key, Value](storagePath, refGen, batch, format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return index.BulkLoad(uniqueEntries)
+	})
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[Key, Value]{}, err
+	}
+
+	return reliable.NewBTree[Key, Value](storagePath, refGen, rawMap, format)
+}
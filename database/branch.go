@@ -0,0 +1,267 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"tstore/data"
+	"tstore/idgen"
+	"tstore/mutation"
+	"tstore/query"
+	"tstore/reliable"
+	"tstore/storage"
+)
+
+// Branch carries on from a commit of main without affecting main until it is merged back.
+type Branch struct {
+	Name string `json:"name"`
+	// BaseCommitID is the commit of main the branch is created from
+	BaseCommitID uint64 `json:"base_commit_id"`
+	// MergedCommitID is the latest commit of the branch merged into main, the merge base of the next merge
+	MergedCommitID uint64 `json:"merged_commit_id"`
+}
+
+type BranchExists string
+
+func (b BranchExists) Error() string {
+	return fmt.Sprintf("branch exists: %v", (string)(b))
+}
+
+var _ error = (*BranchExists)(nil)
+
+type BranchNotFound string
+
+func (b BranchNotFound) Error() string {
+	return fmt.Sprintf("branch not found: %v", (string)(b))
+}
+
+var _ error = (*BranchNotFound)(nil)
+
+type openBranch struct {
+	dataWithVersion *data.WithVersion
+	mutator         *mutation.Mutator
+	queryExecutor   query.Executor
+}
+
+// branches keeps each branch in its own versioned data, which starts with a copy of main at the base commit.
+type branches struct {
+	storagePath      string
+	refGen           *idgen.IDGen
+	rawMap           storage.RawMap
+	format           reliable.Format
+	snapshotInterval int
+	mainData         *data.WithVersion
+	mainMutator      *mutation.Mutator
+	infos            reliable.Map[string, Branch]
+	opened           map[string]*openBranch
+	// mut makes creating & merging a branch atomic
+	mut sync.RWMutex
+}
+
+func (b *branches) create(name string, commitID uint64) error {
+	// branches are stored under their name
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid branch name: %q", name)
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	contain, err := b.infos.Contain(name)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if contain {
+		return BranchExists(fmt.Sprintf("name=%v", name))
+	}
+
+	// clean up the data left by a creation interrupted before the branch was recorded
+	err = b.rawMap.Delete(b.dataPath(name))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	dataWithVersion, err := data.NewWithVersion(b.dataPath(name), b.refGen, b.rawMap, b.format, b.snapshotInterval)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	err = b.mainData.Fork(commitID, dataWithVersion)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	branch, err := b.open(name, dataWithVersion)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	err = b.infos.Set(name, Branch{
+		Name:           name,
+		BaseCommitID:   commitID,
+		MergedCommitID: commitID,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	b.opened[name] = branch
+	return nil
+}
+
+func (b *branches) get(name string) (*openBranch, error) {
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+
+	branch, ok := b.opened[name]
+	if !ok {
+		return nil, BranchNotFound(fmt.Sprintf("name=%v", name))
+	}
+
+	return branch, nil
+}
+
+func (b *branches) list() ([]Branch, error) {
+	names, err := b.infos.Keys()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	sort.Strings(names)
+	branchList := make([]Branch, 0, len(names))
+	for _, name := range names {
+		branch, err := b.infos.Get(name)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		branchList = append(branchList, branch)
+	}
+
+	return branchList, nil
+}
+
+// merge merges the changes made on the branch since its last merge into main,
+// the merge base only moves forward when the branch is merged without conflicts.
+func (b *branches) merge(name string, metadata data.CommitMetadata) (data.MergeResult, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	branch, ok := b.opened[name]
+	if !ok {
+		return data.MergeResult{}, BranchNotFound(fmt.Sprintf("name=%v", name))
+	}
+
+	info, err := b.infos.Get(name)
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	head, err := branch.dataWithVersion.GetLatestCommit()
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	result, err := b.mainMutator.Merge(
+		branch.dataWithVersion,
+		info.MergedCommitID,
+		head.CommittedTransactionID,
+		metadata)
+	if err != nil || result.HasConflicts() {
+		return result, err
+	}
+
+	info.MergedCommitID = head.CommittedTransactionID
+	err = b.infos.Set(name, info)
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	return result, nil
+}
+
+func (b *branches) open(name string, dataWithVersion *data.WithVersion) (*openBranch, error) {
+	mutator, err := mutation.NewBranchMutator(b.mainMutator, b.dataPath(name), b.refGen, b.rawMap, b.format, dataWithVersion)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	mutator.Start()
+	return &openBranch{
+		dataWithVersion: dataWithVersion,
+		mutator:         mutator,
+		queryExecutor:   query.NewExecutor(dataWithVersion),
+	}, nil
+}
+
+func (b *branches) dataPath(name string) string {
+	return path.Join(b.storagePath, "branches", name)
+}
+
+func newBranches(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+	snapshotInterval int,
+	mainData *data.WithVersion,
+	mainMutator *mutation.Mutator,
+) (*branches, error) {
+	infos, err := reliable.NewMap[string, Branch](path.Join(storagePath, "branchInfos"), refGen, rawMap, format)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &branches{
+		storagePath:      storagePath,
+		refGen:           refGen,
+		rawMap:           rawMap,
+		format:           format,
+		snapshotInterval: snapshotInterval,
+		mainData:         mainData,
+		mainMutator:      mainMutator,
+		infos:            infos,
+		opened:           make(map[string]*openBranch),
+	}
+
+	names, err := infos.Keys()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	for _, name := range names {
+		dataWithVersion, err := data.NewWithVersion(b.dataPath(name), refGen, rawMap, format, snapshotInterval)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		branch, err := b.open(name, dataWithVersion)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		b.opened[name] = branch
+	}
+
+	return b, nil
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"time"
+
+	"tstore/data"
+	"tstore/reliable"
+	"tstore/storage"
+)
+
+type Config struct {
+	// Compression is used for the values written from now on, values written before keep their own compression
+	Compression storage.Compression
+	// CollectionFormat is used by the collections created from now on, existing collections keep their own format
+	CollectionFormat reliable.Format
+	// SnapshotInterval is the number of commits between the snapshots of the entities, 0 disables the snapshots
+	SnapshotInterval int
+	// Retention is used by the databases without their own retention policy
+	Retention data.RetentionPolicy
+	// PruneInterval is the time between 2 runs of the pruner, 0 disables the pruner
+	PruneInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Compression:      storage.SnappyCompression,
+		CollectionFormat: reliable.JSONFormat,
+		SnapshotInterval: 100,
+		PruneInterval:    time.Minute,
+	}
+}
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"log"
+	"time"
+
 	"tstore/data"
 	"tstore/history"
 	"tstore/idgen"
@@ -16,27 +19,108 @@ type Database struct {
 	dataWithVersion *data.WithVersion
 	mutator         *mutation.Mutator
 	queryExecutor   query.Executor
+	branches        *branches
+	config          Config
+	stopPruner      chan struct{}
 }
 
 func (d Database) CreateTransaction(transactionInput mutation.TransactionInput) error {
 	return d.mutator.CreateTransaction(transactionInput)
 }
 
-func (d Database) QueryEntitiesAtCommit(commitID uint64, query lang.Expression) ([]data.Entity, error) {
+func (d Database) QueryEntitiesAtCommit(commitRef data.CommitRef, query lang.Expression) ([]data.Entity, error) {
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return nil, err
+	}
+
 	return d.queryExecutor.QueryEntitiesAtCommit(commitID, query)
 }
 
-func (d Database) QueryEntityGroupsAtCommit(commitID uint64, query lang.Expression) (query.Groups[data.Entity], error) {
-	return d.queryExecutor.QueryEntityGroupsAtCommit(commitID, query)
+func (d Database) QueryEntityGroupsAtCommit(
+	commitRef data.CommitRef,
+	groupCollector lang.Expression,
+) (query.Groups[data.Entity], error) {
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.queryExecutor.QueryEntityGroupsAtCommit(commitID, groupCollector)
+}
+
+// QueryEntitiesAsOf queries the entities at the latest commit made at or before the time.
+func (d Database) QueryEntitiesAsOf(at time.Time, query lang.Expression) ([]data.Entity, error) {
+	commit, found, err := d.dataWithVersion.CommitAt(at)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return []data.Entity{}, nil
+	}
+
+	return d.queryExecutor.QueryEntitiesAtCommit(commit.CommittedTransactionID, query)
+}
+
+func (d Database) QueryEntityGroupsAsOf(at time.Time, groupCollector lang.Expression) (query.Groups[data.Entity], error) {
+	commit, found, err := d.dataWithVersion.CommitAt(at)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return make(query.Groups[data.Entity]), nil
+	}
+
+	return d.queryExecutor.QueryEntityGroupsAtCommit(commit.CommittedTransactionID, groupCollector)
 }
 
 func (d Database) QueryEntitiesBetweenCommits(
-	beginCommitID uint64,
-	endCommitID uint64,
+	beginCommitRef data.CommitRef,
+	endCommitRef data.CommitRef,
 	query lang.Expression) (map[uint64][]history.Version[data.Entity], error) {
+	beginCommitID, err := d.dataWithVersion.ResolveCommitRef(beginCommitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	endCommitID, err := d.dataWithVersion.ResolveCommitRef(endCommitRef)
+	if err != nil {
+		return nil, err
+	}
+
 	return d.queryExecutor.QueryEntitiesBetweenCommits(beginCommitID, endCommitID, query)
 }
 
+func (d Database) BlameEntity(commitRef data.CommitRef, entityID uint64) (data.EntityBlame, error) {
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return data.EntityBlame{}, err
+	}
+
+	return d.queryExecutor.BlameEntity(commitID, entityID)
+}
+
+// DiffCommits compares the data at 2 commits, filter selects the entities to compare.
+func (d Database) DiffCommits(
+	fromCommitRef data.CommitRef,
+	toCommitRef data.CommitRef,
+	filter lang.Expression,
+) (data.CommitDiff, error) {
+	fromCommitID, err := d.dataWithVersion.ResolveCommitRef(fromCommitRef)
+	if err != nil {
+		return data.CommitDiff{}, err
+	}
+
+	toCommitID, err := d.dataWithVersion.ResolveCommitRef(toCommitRef)
+	if err != nil {
+		return data.CommitDiff{}, err
+	}
+
+	return d.queryExecutor.DiffCommits(fromCommitID, toCommitID, filter)
+}
+
 func (d Database) GetLatestCommit() (data.Commit, error) {
 	count, err := d.dataWithVersion.CountCommits()
 	if err != nil {
@@ -50,27 +134,219 @@ func (d Database) GetLatestCommit() (data.Commit, error) {
 	return d.dataWithVersion.GetLatestCommit()
 }
 
+// CreateTag names a commit, the tag can't be moved afterwards.
+func (d Database) CreateTag(name string, commitID uint64) error {
+	return d.dataWithVersion.CreateTag(name, commitID)
+}
+
+// ResolveTag returns the commit named by the tag.
+func (d Database) ResolveTag(name string) (uint64, error) {
+	return d.dataWithVersion.ResolveTag(name)
+}
+
+func (d Database) ListTags() ([]data.Tag, error) {
+	return d.dataWithVersion.ListTags()
+}
+
+// Fork copies the schemas & the entities at the commit into the empty target as the first commit of the target,
+// the same way a branch is created, the entities keep their IDs while the history before the commit is left behind.
+func (d Database) Fork(commitRef data.CommitRef, target Database) error {
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return err
+	}
+
+	err = d.dataWithVersion.CheckRetained(commitID)
+	if err != nil {
+		return err
+	}
+
+	return target.mutator.Fork(d.dataWithVersion, commitID)
+}
+
+// CreateBranch creates a branch from a commit of main.
+func (d Database) CreateBranch(name string, commitRef data.CommitRef) error {
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return err
+	}
+
+	return d.branches.create(name, commitID)
+}
+
+func (d Database) ListBranches() ([]Branch, error) {
+	return d.branches.list()
+}
+
+// CreateBranchTransaction commits the transaction to the branch without affecting main.
+func (d Database) CreateBranchTransaction(branchName string, transactionInput mutation.TransactionInput) error {
+	branch, err := d.branches.get(branchName)
+	if err != nil {
+		return err
+	}
+
+	return branch.mutator.CreateTransaction(transactionInput)
+}
+
+// GetBranchHead returns the latest commit of the branch.
+func (d Database) GetBranchHead(branchName string) (data.Commit, error) {
+	branch, err := d.branches.get(branchName)
+	if err != nil {
+		return data.Commit{}, err
+	}
+
+	return branch.dataWithVersion.GetLatestCommit()
+}
+
+// QueryBranchEntitiesAtCommit queries the entities of the branch at the commit, the tags are the ones of main.
+func (d Database) QueryBranchEntitiesAtCommit(
+	branchName string,
+	commitRef data.CommitRef,
+	query lang.Expression,
+) ([]data.Entity, error) {
+	branch, err := d.branches.get(branchName)
+	if err != nil {
+		return nil, err
+	}
+
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return branch.queryExecutor.QueryEntitiesAtCommit(commitID, query)
+}
+
+func (d Database) QueryBranchEntityGroupsAtCommit(
+	branchName string,
+	commitRef data.CommitRef,
+	groupCollector lang.Expression,
+) (query.Groups[data.Entity], error) {
+	branch, err := d.branches.get(branchName)
+	if err != nil {
+		return nil, err
+	}
+
+	commitID, err := d.dataWithVersion.ResolveCommitRef(commitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return branch.queryExecutor.QueryEntityGroupsAtCommit(commitID, groupCollector)
+}
+
+// MergeBranch merges the changes made on the branch since its last merge into main as a single commit.
+// Nothing is committed when main & the branch changed the same attributes in different ways,
+// the conflicts are resolved by a follow-up transaction on either side before merging again.
+func (d Database) MergeBranch(branchName string, metadata data.CommitMetadata) (data.MergeResult, error) {
+	return d.branches.merge(branchName, metadata)
+}
+
+// ListCommits returns a page of the commit log.
+func (d Database) ListCommits(query data.CommitLogQuery) (data.CommitLogPage, error) {
+	return d.dataWithVersion.ListCommits(query)
+}
+
+// CommitAt returns the latest commit made at or before the time, the zero Commit when there is none.
+func (d Database) CommitAt(at time.Time) (data.Commit, error) {
+	commit, _, err := d.dataWithVersion.CommitAt(at)
+	return commit, err
+}
+
+// RetentionPolicy returns the retention policy of the database, which defaults to the one in Config.
+func (d Database) RetentionPolicy() (data.RetentionPolicy, error) {
+	policy, found, err := d.dataWithVersion.RetentionPolicy()
+	if err != nil {
+		return data.RetentionPolicy{}, err
+	}
+
+	if !found {
+		return d.config.Retention, nil
+	}
+
+	return policy, nil
+}
+
+func (d Database) SetRetentionPolicy(policy data.RetentionPolicy) error {
+	return d.dataWithVersion.SetRetentionPolicy(policy)
+}
+
+// Prune removes the versions outside the retention policy right away.
+func (d Database) Prune() error {
+	policy, err := d.RetentionPolicy()
+	if err != nil {
+		return err
+	}
+
+	return d.mutator.Prune(policy)
+}
+
 func (d Database) DeleteAllData() error {
+	close(d.stopPruner)
 	return d.rawMap.Delete(d.storagePath)
 }
 
-func NewDatabase(storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap) (Database, error) {
-	dataWithVersion, err := data.NewWithVersion(storagePath, refGen, rawMap)
+func (d Database) runPruner() {
+	if d.config.PruneInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(d.config.PruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := d.Prune()
+			if err != nil {
+				log.Println(err)
+			}
+		case <-d.stopPruner:
+			return
+		}
+	}
+}
+
+func NewDatabase(storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap, config Config) (Database, error) {
+	compressedMap, err := storage.NewCompressedMap(rawMap, config.Compression)
 	if err != nil {
 		return Database{}, err
 	}
 
-	mutator, err := mutation.NewMutator(storagePath, refGen, rawMap, dataWithVersion)
+	rawMap = compressedMap
+	dataWithVersion, err := data.NewWithVersion(storagePath, refGen, rawMap, config.CollectionFormat, config.SnapshotInterval)
+	if err != nil {
+		return Database{}, err
+	}
+
+	mutator, err := mutation.NewMutator(storagePath, refGen, rawMap, config.CollectionFormat, dataWithVersion)
 	if err != nil {
 		return Database{}, err
 	}
 
 	mutator.Start()
-	return Database{
+	branches, err := newBranches(
+		storagePath,
+		refGen,
+		rawMap,
+		config.CollectionFormat,
+		config.SnapshotInterval,
+		dataWithVersion,
+		mutator)
+	if err != nil {
+		return Database{}, err
+	}
+
+	db := Database{
 		storagePath:     storagePath,
 		rawMap:          rawMap,
 		dataWithVersion: dataWithVersion,
 		mutator:         mutator,
 		queryExecutor:   query.NewExecutor(dataWithVersion),
-	}, nil
+		branches:        branches,
+		config:          config,
+		stopPruner:      make(chan struct{}),
+	}
+	go db.runPruner()
+	return db, nil
 }
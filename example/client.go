@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -132,7 +134,7 @@ func main() {
 		qu := lang.Find(
 			lang.EqualTo(lang.SchemaAttribute, "user"),
 		)
-		entities, err := cl.QueryEntities(dbName, currTransID, qu)
+		entities, err := cl.QueryEntities(dbName, data.CommitIDRef(currTransID), qu)
 		if err != nil {
 			panic(err)
 		}
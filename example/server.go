@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -8,5 +10,7 @@ import (
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	panic(server.StartGRPCServer(8001))
+	config := server.DefaultConfig()
+	config.StorageEngine = server.PageStorageEngine
+	panic(server.StartGRPCServer(8001, config))
 }
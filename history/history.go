@@ -3,6 +3,7 @@ package history
 import (
 	"log"
 	"path"
+	"sort"
 
 	"tstore/idgen"
 	"tstore/reliable"
@@ -14,30 +15,25 @@ type History[
 	CommitID types.Comparable,
 	Value any,
 	Change any] struct {
-	commitsMap    reliable.Map[CommitID, VersionStatus]
-	valueHistory  ValueHistory[CommitID, Value, Change]
-	commitHistory reliable.List[CommitID]
+	valueHistory ValueHistory[CommitID, Value, Change]
+	// commitIndex records the status of every commit ordered by ID, so lookups take a logarithmic number of reads
+	commitIndex reliable.BTree[CommitID, VersionStatus]
 }
 
 func (h History[CommitID, Value, Change]) Value(targetCommitID CommitID) (Value, bool, error) {
-	// TODO: use channel to implement iterator for large data set
-	commits, err := h.commitHistory.Items()
-	if err != nil {
-		log.Println(err)
-		return *new(Value), false, err
-	}
+	iterator := h.commitIndex.DescendFrom(targetCommitID)
+	defer iterator.Close()
 
-	endCommitID, found := findLargestSmallerThan[CommitID](commits, targetCommitID)
-	if !found {
-		return *new(Value), false, nil
-	}
+	if !iterator.Next() {
+		if iterator.Err() != nil {
+			log.Println(iterator.Err())
+		}
 
-	versionStatus, err := h.commitsMap.Get(endCommitID)
-	if err != nil {
-		log.Println(err)
-		return *new(Value), false, err
+		return *new(Value), false, iterator.Err()
 	}
 
+	endCommitID := iterator.Key()
+	versionStatus := iterator.Value()
 	if versionStatus == DeletedVersionStatus {
 		return *new(Value), false, nil
 	} else {
@@ -45,39 +41,74 @@ func (h History[CommitID, Value, Change]) Value(targetCommitID CommitID) (Value,
 	}
 }
 
+// LatestVersion returns the latest version at or before the commit, including deletions.
+func (h History[CommitID, Value, Change]) LatestVersion(targetCommitID CommitID) (CommitVersion[CommitID, Value], bool, error) {
+	iterator := h.commitIndex.DescendFrom(targetCommitID)
+	defer iterator.Close()
+
+	if !iterator.Next() {
+		if iterator.Err() != nil {
+			log.Println(iterator.Err())
+		}
+
+		return CommitVersion[CommitID, Value]{}, false, iterator.Err()
+	}
+
+	version := CommitVersion[CommitID, Value]{
+		CommitID: iterator.Key(),
+		Status:   iterator.Value(),
+	}
+	if version.Status == DeletedVersionStatus {
+		return version, true, nil
+	}
+
+	value, _, err := h.valueHistory.Value(version.CommitID)
+	if err != nil {
+		log.Println(err)
+		return CommitVersion[CommitID, Value]{}, false, err
+	}
+
+	version.Value = value
+	return version, true, nil
+}
+
 func (h History[CommitID, Value, Change]) ChangesBetween(
 	beginCommitID CommitID,
 	endCommitID CommitID,
 ) ([]Version[Value], error) {
-	commits, err := h.commitHistory.Items()
-	if err != nil {
-		log.Println(err)
-		return []Version[Value]{}, err
-	}
+	iterator := h.commitIndex.AscendFrom(beginCommitID)
+	defer iterator.Close()
 
-	inBetweenCommitIDs := findAllInBetween(commits, beginCommitID, endCommitID)
 	var versions []Version[Value]
 
-	for _, commitID := range inBetweenCommitIDs {
-		versionStatus, err := h.commitsMap.Get(commitID)
-		if err != nil {
-			log.Println(err)
-			return []Version[Value]{}, err
-		}
-
-		value, _, err := h.valueHistory.Value(commitID)
-		if err != nil {
-			log.Println(err)
-			return []Version[Value]{}, err
+	for iterator.Next() {
+		commitID := iterator.Key()
+		if commitID > endCommitID {
+			break
 		}
 
 		version := Version[Value]{
-			Status: versionStatus,
-			Value:  value,
+			Status: iterator.Value(),
+		}
+		// a version deleted later in its commit keeps no value
+		if version.Status != DeletedVersionStatus {
+			value, _, err := h.valueHistory.Value(commitID)
+			if err != nil {
+				log.Println(err)
+				return []Version[Value]{}, err
+			}
+
+			version.Value = value
 		}
+
 		versions = append(versions, version)
 	}
 
+	if iterator.Err() != nil {
+		log.Println(iterator.Err())
+		return []Version[Value]{}, iterator.Err()
+	}
+
 	return versions, nil
 }
 
@@ -86,14 +117,14 @@ func (h *History[CommitID, Value, Change]) AddVersion(
 	versionStatus VersionStatus,
 	change Change,
 ) (bool, error) {
-	contain, err := h.commitsMap.Contain(commitID)
+	currStatus, contain, err := h.commitIndex.Get(commitID)
 	if err != nil {
 		log.Println(err)
 		return false, err
 	}
 
 	if contain {
-		return false, nil
+		return h.amendVersion(commitID, currStatus, versionStatus, change)
 	}
 
 	var updated bool
@@ -105,47 +136,105 @@ func (h *History[CommitID, Value, Change]) AddVersion(
 		}
 	}
 
-	err = h.commitHistory.Append(commitID)
-	if err != nil {
-		log.Println(err)
-		return false, err
+	// the commit becomes visible to Value once it is in the commit index,
+	// so it is indexed last
+	return updated, h.commitIndex.Put(commitID, versionStatus)
+}
+
+// amendVersion applies a later change of the same commit on top of the version of the commit.
+// The version stays created when it is updated afterwards.
+func (h *History[CommitID, Value, Change]) amendVersion(
+	commitID CommitID,
+	currStatus VersionStatus,
+	versionStatus VersionStatus,
+	change Change,
+) (bool, error) {
+	if versionStatus == UpdatedVersionStatus && currStatus == CreatedVersionStatus {
+		versionStatus = CreatedVersionStatus
 	}
 
-	return updated, h.commitsMap.Set(commitID, versionStatus)
+	var updated bool
+	if versionStatus != DeletedVersionStatus {
+		var err error
+		updated, err = h.valueHistory.AddVersion(commitID, change)
+		if err != nil {
+			log.Println(err)
+			return false, err
+		}
+	}
+
+	if versionStatus == currStatus {
+		return updated, nil
+	}
+
+	return true, h.commitIndex.Put(commitID, versionStatus)
 }
 
 func (h *History[CommitID, Value, Change]) RemoveVersion(commitID CommitID) (bool, error) {
-	contain, err := h.commitsMap.Contain(commitID)
+	deleted, err := h.commitIndex.Delete(commitID)
 	if err != nil {
 		log.Println(err)
 		return false, err
 	}
 
-	if contain {
-		return false, nil
-	}
-
+	// AddVersion may fail before the commit is indexed, so the value history is cleaned up regardless
 	removed, err := h.valueHistory.RemoveVersion(commitID)
 	if err != nil {
 		log.Println(err)
 		return false, err
 	}
 
-	commitLen, err := h.commitHistory.Length()
+	return deleted || removed, nil
+}
+
+// Prune collapses the versions before horizon into the version at horizon,
+// so the value stays the same at horizon and any later commit. It returns whether the history becomes empty.
+//
+// The commit index is updated last, so pruning again after a failure cleans up the remaining versions.
+func (h *History[CommitID, Value, Change]) Prune(horizon CommitID) (bool, error) {
+	baseIterator := h.commitIndex.DescendFrom(horizon)
+	defer baseIterator.Close()
+
+	if !baseIterator.Next() {
+		if baseIterator.Err() != nil {
+			log.Println(baseIterator.Err())
+			return false, baseIterator.Err()
+		}
+
+		return false, nil
+	}
+
+	baseCommitID := baseIterator.Key()
+	baseDeleted := baseIterator.Value() == DeletedVersionStatus
+	err := h.valueHistory.Prune(baseCommitID)
 	if err != nil {
 		log.Println(err)
 		return false, err
 	}
 
-	if commitLen > 0 {
-		_, err = h.commitHistory.Pop()
+	iterator := h.commitIndex.Ascend()
+	defer iterator.Close()
+
+	prunedCommitIDs := make(map[CommitID]bool)
+	for iterator.Next() && (iterator.Key() < baseCommitID || (baseDeleted && iterator.Key() == baseCommitID)) {
+		prunedCommitIDs[iterator.Key()] = true
+	}
+
+	if iterator.Err() != nil {
+		log.Println(iterator.Err())
+		return false, iterator.Err()
+	}
+
+	for commitID := range prunedCommitIDs {
+		_, err = h.commitIndex.Delete(commitID)
 		if err != nil {
 			log.Println(err)
 			return false, err
 		}
 	}
 
-	return removed, h.commitsMap.Delete(commitID)
+	length, err := h.commitIndex.Len()
+	return length == 0, err
 }
 
 func New[
@@ -155,53 +244,138 @@ func New[
 	storagePath string,
 	refGen *idgen.IDGen,
 	rawMap storage.RawMap,
+	format reliable.Format,
 	createValueHistory func(storagePath string) (ValueHistory[CommitID, Value, Change], error),
 ) (*History[CommitID, Value, Change], error) {
-	commitHistoryPath := path.Join(storagePath, "commitHistory")
-	commitIDs, err := reliable.NewList[CommitID](commitHistoryPath, refGen, rawMap)
-	if err != nil {
-		log.Println(err)
-		return nil, err
-	}
-
 	valueHistory, err := createValueHistory(path.Join(storagePath, "valueHistory"))
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	reliableMap, err := reliable.NewMap[CommitID, VersionStatus](path.Join(storagePath, "commits"), refGen, rawMap)
+	commitIndex, err := openCommitIndex[CommitID](storagePath, refGen, rawMap, format)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
 	return &History[CommitID, Value, Change]{
-		commitsMap:    reliableMap,
-		valueHistory:  valueHistory,
-		commitHistory: commitIDs,
+		valueHistory: valueHistory,
+		commitIndex:  commitIndex,
 	}, nil
 }
 
-func findAllInBetween[Item types.Comparable](sortedItems []Item, begin Item, end Item) []Item {
-	between := make([]Item, 0)
+// openCommitIndex opens the commit index of a history.
+// Histories written before the index existed keep their commits in a list with their statuses in a map,
+// they are migrated by bulk loading the index & dropping the list & the map.
+func openCommitIndex[CommitID types.Comparable](
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+) (reliable.BTree[CommitID, VersionStatus], error) {
+	indexPath := path.Join(storagePath, "commitIndex")
+	commitHistoryPath := path.Join(storagePath, "commitHistory")
+	commitsMapPath := path.Join(storagePath, "commits")
+	contain, err := rawMap.Contain(indexPath)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[CommitID, VersionStatus]{}, err
+	}
+
+	legacy, err := rawMap.Contain(commitHistoryPath)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[CommitID, VersionStatus]{}, err
+	}
+
+	if contain || !legacy {
+		return reliable.NewBTree[CommitID, VersionStatus](indexPath, refGen, rawMap, format)
+	}
+
+	commitHistory, err := reliable.NewList[CommitID](commitHistoryPath, refGen, rawMap, format)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[CommitID, VersionStatus]{}, err
+	}
 
-	for _, item := range sortedItems {
-		if item >= begin && item <= end {
-			between = append(between, item)
+	commitsMap, err := reliable.NewMap[CommitID, VersionStatus](commitsMapPath, refGen, rawMap, format)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[CommitID, VersionStatus]{}, err
+	}
+
+	entries := make([]reliable.BTreeEntry[CommitID, VersionStatus], 0)
+	iterator := commitHistory.Iterator()
+	defer iterator.Close()
+
+	for iterator.Next() {
+		commitID := iterator.Item()
+		contain, err := commitsMap.Contain(commitID)
+		if err != nil {
+			log.Println(err)
+			return reliable.BTree[CommitID, VersionStatus]{}, err
+		}
+
+		// a commit appended without its status was never visible
+		if !contain {
+			continue
+		}
+
+		versionStatus, err := commitsMap.Get(commitID)
+		if err != nil {
+			log.Println(err)
+			return reliable.BTree[CommitID, VersionStatus]{}, err
 		}
+
+		entries = append(entries, reliable.BTreeEntry[CommitID, VersionStatus]{
+			Key:   commitID,
+			Value: versionStatus,
+		})
 	}
 
-	return between
-}
+	if iterator.Err() != nil {
+		log.Println(iterator.Err())
+		return reliable.BTree[CommitID, VersionStatus]{}, iterator.Err()
+	}
 
-func findLargestSmallerThan[Item types.Comparable](sortedItems []Item, end Item) (Item, bool) {
-	for index := len(sortedItems) - 1; index >= 0; index-- {
-		item := sortedItems[index]
-		if item <= end {
-			return item, true
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	// a commit appended again after a failure is only indexed once
+	uniqueEntries := make([]reliable.BTreeEntry[CommitID, VersionStatus], 0, len(entries))
+	for _, entry := range entries {
+		if len(uniqueEntries) > 0 && uniqueEntries[len(uniqueEntries)-1].Key == entry.Key {
+			continue
+		}
+
+		uniqueEntries = append(uniqueEntries, entry)
+	}
+
+	// the index is created & loaded in one batch, so a crash never leaves a partially migrated index
+	err = storage.RunInBatch(rawMap, func(batch storage.RawMap) error {
+		commitIndex, err := reliable.NewBTree[CommitID, VersionStatus](indexPath, refGen, batch, format)
+		if err != nil {
+			return err
 		}
+
+		err = commitIndex.BulkLoad(uniqueEntries)
+		if err != nil {
+			return err
+		}
+
+		err = batch.Delete(commitHistoryPath)
+		if err != nil {
+			return err
+		}
+
+		return batch.Delete(commitsMapPath)
+	})
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[CommitID, VersionStatus]{}, err
 	}
 
-	return *new(Item), false
+	return reliable.NewBTree[CommitID, VersionStatus](indexPath, refGen, rawMap, format)
 }
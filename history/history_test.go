@@ -1,10 +1,13 @@
 package history
 
 import (
+	"fmt"
+	"math"
 	"path"
 	"testing"
 
 	"tstore/idgen"
+	"tstore/reliable"
 	"tstore/storage"
 
 	"github.com/stretchr/testify/assert"
@@ -19,8 +22,9 @@ func TestHistory(t *testing.T) {
 		"data",
 		refGen,
 		rawMap,
+		reliable.JSONFormat,
 		func(storagePath string) (ValueHistory[uint64, string, string], error) {
-			return NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap)
+			return NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap, reliable.JSONFormat)
 		})
 	assert.Nil(t, err)
 
@@ -73,3 +77,203 @@ func TestHistory(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, versions[0:4], versions2)
 }
+
+func TestHistory_AmendVersion(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	hist, err := New[uint64, string, string](
+		"data",
+		refGen,
+		rawMap,
+		reliable.JSONFormat,
+		func(storagePath string) (ValueHistory[uint64, string, string], error) {
+			return NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap, reliable.JSONFormat)
+		})
+	assert.Nil(t, err)
+
+	// later changes of the same commit apply on top of the version of the commit
+	_, err = hist.AddVersion(1, CreatedVersionStatus, "Harry")
+	assert.Nil(t, err)
+	_, err = hist.AddVersion(1, UpdatedVersionStatus, "Potter")
+	assert.Nil(t, err)
+	_, err = hist.AddVersion(2, UpdatedVersionStatus, "Cool")
+	assert.Nil(t, err)
+	_, err = hist.AddVersion(2, DeletedVersionStatus, "")
+	assert.Nil(t, err)
+
+	_, ok, err := hist.Value(2)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	versions, err := hist.ChangesBetween(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []Version[string]{
+		{
+			Status: CreatedVersionStatus,
+			Value:  "Harry",
+		},
+		{
+			Status: DeletedVersionStatus,
+		},
+	}, versions)
+}
+
+func TestHistory_CommitIndexMigration(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	openHistory := func() *History[uint64, string, string] {
+		hist, err := New[uint64, string, string](
+			"data",
+			refGen,
+			rawMap,
+			reliable.JSONFormat,
+			func(storagePath string) (ValueHistory[uint64, string, string], error) {
+				return NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap, reliable.JSONFormat)
+			})
+		assert.Nil(t, err)
+		return hist
+	}
+
+	// histories written before the commit index existed keep their commits in a list & their statuses in a map
+	commitHistory, err := reliable.NewList[uint64](path.Join("data", "commitHistory"), refGen, rawMap, reliable.JSONFormat)
+	assert.Nil(t, err)
+	commitsMap, err := reliable.NewMap[uint64, VersionStatus](path.Join("data", "commits"), refGen, rawMap, reliable.JSONFormat)
+	assert.Nil(t, err)
+	valueHistory, err := NewSingleValueHistory[uint64, string](path.Join("data", "valueHistory"), refGen, rawMap, reliable.JSONFormat)
+	assert.Nil(t, err)
+	for commitID := uint64(10); commitID <= 200; commitID += 10 {
+		status := UpdatedVersionStatus
+		if commitID%30 == 0 {
+			status = DeletedVersionStatus
+		} else {
+			_, err = valueHistory.AddVersion(commitID, fmt.Sprint(commitID))
+			assert.Nil(t, err)
+		}
+
+		assert.Nil(t, commitsMap.Set(commitID, status))
+		assert.Nil(t, commitHistory.Append(commitID))
+	}
+
+	hist := openHistory()
+	contain, err := rawMap.Contain(path.Join("data", "commitIndex"))
+	assert.Nil(t, err)
+	assert.True(t, contain)
+
+	for _, legacyPath := range []string{"commitHistory", "commits"} {
+		contain, err = rawMap.Contain(path.Join("data", legacyPath))
+		assert.Nil(t, err)
+		assert.False(t, contain, legacyPath)
+	}
+
+	value, ok, err := hist.Value(115)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "110", value)
+
+	_, ok, err = hist.Value(125)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = hist.Value(5)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	versions, err := hist.ChangesBetween(45, 75)
+	assert.Nil(t, err)
+	assert.Equal(t, []Version[string]{
+		{Status: UpdatedVersionStatus, Value: "50"},
+		{Status: DeletedVersionStatus},
+		{Status: UpdatedVersionStatus, Value: "70"},
+	}, versions)
+
+	removed, err := hist.RemoveVersion(200)
+	assert.Nil(t, err)
+	assert.True(t, removed)
+
+	value, ok, err = hist.Value(math.MaxUint64)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "190", value)
+}
+
+func TestKeyValue_Prune(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	keyValue, err := NewKeyValue[uint64, string, string, string](
+		"data",
+		refGen,
+		rawMap,
+		reliable.JSONFormat,
+		func(storagePath string) (ValueHistory[uint64, string, string], error) {
+			return NewSingleValueHistory[uint64, string](storagePath, refGen, rawMap, reliable.JSONFormat)
+		})
+	assert.Nil(t, err)
+
+	addVersion := func(commitID uint64, key string, status VersionStatus, value string) {
+		_, err := keyValue.AddVersion(commitID, key, status, value)
+		assert.Nil(t, err)
+	}
+
+	addVersion(1, "a", CreatedVersionStatus, "a1")
+	addVersion(2, "a", UpdatedVersionStatus, "a2")
+	addVersion(5, "a", UpdatedVersionStatus, "a5")
+	addVersion(1, "b", CreatedVersionStatus, "b1")
+	addVersion(3, "b", DeletedVersionStatus, "")
+	addVersion(2, "c", CreatedVersionStatus, "c2")
+	addVersion(3, "c", DeletedVersionStatus, "")
+	addVersion(6, "c", CreatedVersionStatus, "c6")
+
+	expected := make(map[uint64]map[string]string)
+	for commitID := uint64(4); commitID <= 7; commitID++ {
+		expected[commitID], _, err = keyValue.ListAllLatestValuesAt(commitID)
+		assert.Nil(t, err)
+	}
+
+	assert.Nil(t, keyValue.Prune(4))
+	// pruning again is a no-op
+	assert.Nil(t, keyValue.Prune(4))
+
+	for commitID := uint64(4); commitID <= 7; commitID++ {
+		values, _, err := keyValue.ListAllLatestValuesAt(commitID)
+		assert.Nil(t, err)
+		assert.Equal(t, expected[commitID], values)
+	}
+
+	keys, err := keyValue.historyKeys.Keys()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "c"}, keys)
+
+	contain, err := rawMap.Contain(keyValue.historyPath("b"))
+	assert.Nil(t, err)
+	assert.False(t, contain)
+
+	versions, err := keyValue.FindChangesBetween(0, 10, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []Version[string]{
+		{Status: UpdatedVersionStatus, Value: "a2"},
+		{Status: UpdatedVersionStatus, Value: "a5"},
+	}, versions)
+
+	versions, err = keyValue.FindChangesBetween(0, 10, "c")
+	assert.Nil(t, err)
+	assert.Equal(t, []Version[string]{{Status: CreatedVersionStatus, Value: "c6"}}, versions)
+
+	hist, err := keyValue.getHistory("a")
+	assert.Nil(t, err)
+	iterator := hist.commitIndex.Ascend()
+	defer iterator.Close()
+
+	commitIDs := make([]uint64, 0)
+	for iterator.Next() {
+		commitIDs = append(commitIDs, iterator.Key())
+	}
+
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []uint64{2, 5}, commitIDs)
+}
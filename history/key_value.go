@@ -19,6 +19,7 @@ type KeyValue[
 	storagePath        string
 	refGen             *idgen.IDGen
 	rawMap             storage.RawMap
+	format             reliable.Format
 	historyKeys        reliable.Map[Key, bool]
 	createValueHistory func(storagePath string) (ValueHistory[CommitID, Value, Change], error)
 }
@@ -46,13 +47,11 @@ func (k KeyValue[CommitID, Key, Value, Change]) FindLatestValueAt(targetCommitID
 func (k KeyValue[CommitID, Key, Value, Change]) ListAllLatestValuesAt(targetCommitID CommitID) (map[Key]Value, bool, error) {
 	pairs := make(map[Key]Value)
 	var present bool
-	keys, err := k.historyKeys.Keys()
-	if err != nil {
-		log.Println(err)
-		return nil, false, err
-	}
+	keys := k.historyKeys.KeyIterator()
+	defer keys.Close()
 
-	for _, key := range keys {
+	for keys.Next() {
+		key := keys.Item()
 		hist, err := k.getHistory(key)
 		if err != nil {
 			log.Println(err)
@@ -65,13 +64,76 @@ func (k KeyValue[CommitID, Key, Value, Change]) ListAllLatestValuesAt(targetComm
 			return nil, false, err
 		}
 
-		present = present || valuePresent
-		pairs[key] = value
+		if valuePresent {
+			present = true
+			pairs[key] = value
+		}
+	}
+
+	if keys.Err() != nil {
+		log.Println(keys.Err())
+		return nil, false, keys.Err()
 	}
 
 	return pairs, present, nil
 }
 
+// ListAllLatestVersionsAt returns the latest version of every key present at the commit.
+func (k KeyValue[CommitID, Key, Value, Change]) ListAllLatestVersionsAt(
+	targetCommitID CommitID,
+) (map[Key]CommitVersion[CommitID, Value], error) {
+	versions := make(map[Key]CommitVersion[CommitID, Value])
+	keys := k.historyKeys.KeyIterator()
+	defer keys.Close()
+
+	for keys.Next() {
+		key := keys.Item()
+		hist, err := k.getHistory(key)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		version, found, err := hist.LatestVersion(targetCommitID)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if found && version.Status != DeletedVersionStatus {
+			versions[key] = version
+		}
+	}
+
+	if keys.Err() != nil {
+		log.Println(keys.Err())
+		return nil, keys.Err()
+	}
+
+	return versions, nil
+}
+
+// FindValueHistory returns the value history of the key, false when the key has no history.
+func (k KeyValue[CommitID, Key, Value, Change]) FindValueHistory(key Key) (ValueHistory[CommitID, Value, Change], bool, error) {
+	contain, err := k.historyKeys.Contain(key)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	if !contain {
+		return nil, false, nil
+	}
+
+	hist, err := k.getHistory(key)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	return hist.valueHistory, true, nil
+}
+
 func (k KeyValue[CommitID, Key, Value, Change]) FindChangesBetween(
 	beginCommitID CommitID,
 	endCommitID CommitID,
@@ -101,15 +163,17 @@ func (k KeyValue[CommitID, Key, Value, Change]) FindAllChangesBetween(
 	endCommitID CommitID,
 ) (map[Key][]Version[Value], error) {
 	values := make(map[Key][]Version[Value])
+	keys := k.historyKeys.KeyIterator()
+	defer keys.Close()
 
-	keys, err := k.historyKeys.Keys()
-	if err != nil {
-		log.Println(err)
-		return nil, err
-	}
-
-	for _, key := range keys {
+	for keys.Next() {
+		key := keys.Item()
 		hist, err := k.getHistory(key)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
 		versions, err := hist.ChangesBetween(beginCommitID, endCommitID)
 		if err != nil {
 			log.Println(err)
@@ -119,6 +183,11 @@ func (k KeyValue[CommitID, Key, Value, Change]) FindAllChangesBetween(
 		values[key] = versions
 	}
 
+	if keys.Err() != nil {
+		log.Println(keys.Err())
+		return nil, keys.Err()
+	}
+
 	return values, nil
 }
 
@@ -172,13 +241,11 @@ func (k KeyValue[CommitID, Key, Value, Change]) AddVersion(
 
 func (k KeyValue[CommitID, Key, Value, Change]) RemoveVersion(commitID CommitID) (bool, error) {
 	var hasDeletion bool
-	keys, err := k.historyKeys.Keys()
-	if err != nil {
-		log.Println(err)
-		return false, err
-	}
+	keys := k.historyKeys.KeyIterator()
+	defer keys.Close()
 
-	for _, key := range keys {
+	for keys.Next() {
+		key := keys.Item()
 		hist, err := k.getHistory(key)
 		if err != nil {
 			log.Println(err)
@@ -194,12 +261,68 @@ func (k KeyValue[CommitID, Key, Value, Change]) RemoveVersion(commitID CommitID)
 		hasDeletion = hasDeletion || removed
 	}
 
+	if keys.Err() != nil {
+		log.Println(keys.Err())
+		return false, keys.Err()
+	}
+
 	return hasDeletion, nil
 }
 
+// Prune collapses the versions of every key before horizon and removes the keys without any version left.
+func (k KeyValue[CommitID, Key, Value, Change]) Prune(horizon CommitID) error {
+	emptyKeys := make([]Key, 0)
+	keys := k.historyKeys.KeyIterator()
+	defer keys.Close()
+
+	for keys.Next() {
+		key := keys.Item()
+		hist, err := k.getHistory(key)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		empty, err := hist.Prune(horizon)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if empty {
+			emptyKeys = append(emptyKeys, key)
+		}
+	}
+
+	if keys.Err() != nil {
+		log.Println(keys.Err())
+		return keys.Err()
+	}
+
+	// a key left without its history after a failure is found empty & removed by the next prune
+	for _, key := range emptyKeys {
+		err := k.rawMap.Delete(k.historyPath(key))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = k.historyKeys.Delete(key)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k KeyValue[CommitID, Key, Value, Change]) historyPath(key Key) string {
+	return path.Join(k.storagePath, "histories", fmt.Sprintf("%v", key))
+}
+
 func (k KeyValue[CommitID, Key, Value, Change]) getHistory(key Key) (*History[CommitID, Value, Change], error) {
-	historyPath := path.Join(k.storagePath, "histories", fmt.Sprintf("%v", key))
-	return New[CommitID, Value, Change](historyPath, k.refGen, k.rawMap, k.createValueHistory)
+	return New[CommitID, Value, Change](k.historyPath(key), k.refGen, k.rawMap, k.format, k.createValueHistory)
 }
 
 func NewKeyValue[
@@ -210,9 +333,10 @@ func NewKeyValue[
 	storagePath string,
 	refGen *idgen.IDGen,
 	rawMap storage.RawMap,
+	format reliable.Format,
 	createValueHistory func(storagePath string) (ValueHistory[CommitID, Value, Change], error),
 ) (KeyValue[CommitID, Key, Value, Change], error) {
-	historyKeys, err := reliable.NewMap[Key, bool](path.Join(storagePath, "historyKeys"), refGen, rawMap)
+	historyKeys, err := reliable.NewMap[Key, bool](path.Join(storagePath, "historyKeys"), refGen, rawMap, format)
 	if err != nil {
 		return *new(KeyValue[CommitID, Key, Value, Change]), err
 	}
@@ -221,6 +345,7 @@ func NewKeyValue[
 		storagePath:        storagePath,
 		refGen:             refGen,
 		rawMap:             rawMap,
+		format:             format,
 		historyKeys:        historyKeys,
 		createValueHistory: createValueHistory,
 	}, nil
@@ -15,6 +15,8 @@ type ValueHistory[CommitID types.Comparable, Value any, Change any] interface {
 	Value(commitID CommitID) (Value, bool, error)
 	AddVersion(commitID CommitID, change Change) (bool, error)
 	RemoveVersion(commitID CommitID) (bool, error)
+	// Prune removes the versions which are not needed to read the value at baseCommitID or any later commit
+	Prune(baseCommitID CommitID) error
 }
 
 type SingleValueHistory[CommitID types.Comparable, Value any] struct {
@@ -66,12 +68,41 @@ func (s SingleValueHistory[CommitID, Value]) RemoveVersion(commitID CommitID) (b
 	return true, s.commitsMap.Delete(commitID)
 }
 
+// Prune removes the values before baseCommitID since a value is only read at the commit it was added
+func (s SingleValueHistory[CommitID, Value]) Prune(baseCommitID CommitID) error {
+	iterator := s.commitsMap.KeyIterator()
+	defer iterator.Close()
+
+	prunedCommitIDs := make([]CommitID, 0)
+	for iterator.Next() {
+		if iterator.Item() < baseCommitID {
+			prunedCommitIDs = append(prunedCommitIDs, iterator.Item())
+		}
+	}
+
+	if iterator.Err() != nil {
+		log.Println(iterator.Err())
+		return iterator.Err()
+	}
+
+	for _, commitID := range prunedCommitIDs {
+		err := s.commitsMap.Delete(commitID)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func NewSingleValueHistory[CommitID types.Comparable, Value any](
 	storagePath string,
 	refGen *idgen.IDGen,
 	rawMap storage.RawMap,
+	format reliable.Format,
 ) (*SingleValueHistory[CommitID, Value], error) {
-	commitsMap, err := reliable.NewMap[CommitID, Value](path.Join(storagePath, "commits"), refGen, rawMap)
+	commitsMap, err := reliable.NewMap[CommitID, Value](path.Join(storagePath, "commits"), refGen, rawMap, format)
 	if err != nil {
 		log.Println(err)
 		return nil, err
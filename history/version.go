@@ -1,5 +1,9 @@
 package history
 
+import (
+	"tstore/types"
+)
+
 type VersionStatus string
 
 const (
@@ -12,3 +16,10 @@ type Version[Value any] struct {
 	Status VersionStatus `json:"status"`
 	Value  Value         `json:"value"`
 }
+
+// CommitVersion is a version along with the commit which produced it
+type CommitVersion[CommitID types.Comparable, Value any] struct {
+	CommitID CommitID      `json:"commit_id"`
+	Status   VersionStatus `json:"status"`
+	Value    Value         `json:"value"`
+}
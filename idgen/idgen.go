@@ -2,11 +2,13 @@ package idgen
 
 import (
 	"encoding/json"
+	"sync"
 
 	"tstore/storage"
 )
 
 type IDGen struct {
+	mut         sync.Mutex
 	storagePath string
 	rawMap      storage.RawMap
 	bufferSize  int
@@ -15,6 +17,9 @@ type IDGen struct {
 }
 
 func (i *IDGen) NextID() (uint64, error) {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
 	if i.nextID > i.rangeEnd {
 		nextID := i.nextID + uint64(i.bufferSize)
 		rangeEnd := nextID - 1
@@ -32,11 +37,34 @@ func (i *IDGen) NextID() (uint64, error) {
 	return id, nil
 }
 
-func (i IDGen) writeNextID(nextID uint64) error {
+// SkipTo makes the IDs generated from now on greater than id.
+func (i *IDGen) SkipTo(id uint64) error {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	if id < i.nextID {
+		return nil
+	}
+
+	if id >= i.rangeEnd {
+		nextID := id + 1 + uint64(i.bufferSize)
+		err := i.writeNextID(nextID)
+		if err != nil {
+			return err
+		}
+
+		i.rangeEnd = nextID - 1
+	}
+
+	i.nextID = id + 1
+	return nil
+}
+
+func (i *IDGen) writeNextID(nextID uint64) error {
 	return writeNextID(i.storagePath, i.rawMap, nextID)
 }
 
-func (i IDGen) readNextID() (uint64, error) {
+func (i *IDGen) readNextID() (uint64, error) {
 	return readNextID(i.storagePath, i.rawMap)
 }
 
@@ -2,6 +2,8 @@ package mutation
 
 import (
 	"fmt"
+
+	"tstore/data"
 )
 
 type SchemaNotFound string
@@ -11,3 +13,21 @@ func (s SchemaNotFound) Error() string {
 }
 
 var _ error = (*SchemaNotFound)(nil)
+
+// RevertConflicts reports the changes of the reverted commit which later commits changed again.
+// MainValue & MainType are the latest ones, while BranchValue & BranchType are the ones before the reverted commit.
+type RevertConflicts struct {
+	CommitID        uint64
+	EntityConflicts []data.EntityConflict
+	SchemaConflicts []data.SchemaConflict
+}
+
+func (r RevertConflicts) Error() string {
+	return fmt.Sprintf(
+		"revert conflicts: commitID=%v, entityConflicts=%v, schemaConflicts=%v",
+		r.CommitID,
+		r.EntityConflicts,
+		r.SchemaConflicts)
+}
+
+var _ error = (*RevertConflicts)(nil)
@@ -6,4 +6,5 @@ import (
 
 type TransactionInput struct {
 	Mutations map[string][]data.Mutation `json:"mutations"` // key: schema name, value: mutation
+	Metadata  data.CommitMetadata        `json:"metadata"`
 }
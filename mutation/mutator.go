@@ -1,9 +1,12 @@
 package mutation
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"path"
+	"sort"
+	"sync"
 	"time"
 
 	"tstore/data"
@@ -19,64 +22,291 @@ const transactionBufferSize = 500
 const idGenBufferSize = 100
 
 type Mutator struct {
-	dataWithVersion        *data.WithVersion
-	entityIDGen            *idgen.IDGen
-	transactionIDGen       *idgen.IDGen
-	transactions           reliable.List[Transaction]
-	transactionStatus      reliable.Map[uint64, TransactionStatus]
-	incomingTransactions   chan Transaction
+	dataWithVersion   *data.WithVersion
+	entityIDGen       *idgen.IDGen
+	transactionIDGen  *idgen.IDGen
+	rawMap            storage.RawMap
+	transactions      reliable.BTree[uint64, Transaction] // key: transaction ID
+	transactionStatus reliable.Map[uint64, TransactionStatus]
+	// finishedPath stores the low-water mark of the finished transactions, the recovery starts after it
+	finishedPath           string
+	incomingTransactions   chan queuedTransaction
+	incomingPrunes         chan pruneRequest
 	onTransactionProcessed chan uint64
+	// queueMut makes taking the ID of a transaction & queuing it atomic,
+	// so the transactions are committed in the order of their IDs, it also guards storing the transactions
+	queueMut *sync.Mutex
+}
+
+// queuedTransaction is a transaction waiting for its turn, the mutations of a merge are planned on its turn.
+type queuedTransaction struct {
+	transaction Transaction
+	merge       *mergeRequest
+}
+
+type pruneRequest struct {
+	policy data.RetentionPolicy
+	done   chan error
+}
+
+type mergeRequest struct {
+	branch         *data.WithVersion
+	baseCommitID   uint64
+	branchCommitID uint64
+	done           chan mergeResponse
+}
+
+type mergeResponse struct {
+	result data.MergeResult
+	err    error
 }
 
 func (m Mutator) CreateTransaction(transactionInput TransactionInput) error {
+	return m.enqueue(Transaction{
+		Mutations: withoutEntityIDs(transactionInput.Mutations),
+		Metadata:  transactionInput.Metadata,
+	}, nil)
+}
+
+// enqueue queues the transaction with the next ID. A merge is only stored once its mutations are planned.
+func (m Mutator) enqueue(transaction Transaction, merge *mergeRequest) error {
+	m.queueMut.Lock()
+	defer m.queueMut.Unlock()
+
 	id, err := m.transactionIDGen.NextID()
 	if err != nil {
 		return err
 	}
 
-	ts := Transaction{
-		ID:        id,
-		Mutations: transactionInput.Mutations,
-	}
-
-	err = m.transactions.Append(ts)
-	if err != nil {
-		return err
+	transaction.ID = id
+	if merge == nil {
+		err = m.transactions.Put(transaction.ID, transaction)
+		if err != nil {
+			return err
+		}
 	}
 
-	m.incomingTransactions <- ts
+	m.incomingTransactions <- queuedTransaction{transaction: transaction, merge: merge}
 	return nil
 }
 
+// storeTransaction stores a transaction whose mutations are planned on its turn,
+// the transactions being queued are stored at the same time.
+func (m Mutator) storeTransaction(transaction Transaction) error {
+	m.queueMut.Lock()
+	defer m.queueMut.Unlock()
+
+	return m.transactions.Put(transaction.ID, transaction)
+}
+
 func (m *Mutator) Start() {
 	go func() {
-		for transaction := range m.incomingTransactions {
-			err := m.commitTransaction(transaction)
-			if err == nil {
-				err = m.transactionStatus.Set(transaction.ID, transactionCommitted)
-				if err != nil {
-					log.Println(err)
-				}
-			} else {
-				log.Printf("fail to commit transaction: transaction=%v error=%v\n", transaction.ID, err)
-				err = m.rollbackTransaction(transaction.ID)
-				if err != nil {
-					log.Println(err)
+		// the mark stops before the first unfinished transaction
+		markable := true
+		for {
+			select {
+			case queued := <-m.incomingTransactions:
+				if queued.merge == nil {
+					m.processTransaction(queued.transaction)
 				} else {
-					err = m.transactionStatus.Set(transaction.ID, transactionAborted)
-					if err != nil {
-						log.Println(err)
-					}
+					result, err := m.merge(queued.transaction, *queued.merge)
+					queued.merge.done <- mergeResponse{result: result, err: err}
 				}
-			}
 
-			go func(transaction Transaction) {
-				m.onTransactionProcessed <- transaction.ID
-			}(transaction)
+				if markable {
+					markable = m.markFinished(queued.transaction.ID)
+				}
+			case request := <-m.incomingPrunes:
+				request.done <- m.dataWithVersion.Prune(request.policy, time.Now())
+			}
 		}
 	}()
 }
 
+// Prune prunes the versions outside the retention policy between 2 transactions, the mutator must be started.
+func (m *Mutator) Prune(policy data.RetentionPolicy) error {
+	request := pruneRequest{
+		policy: policy,
+		done:   make(chan error, 1),
+	}
+	m.incomingPrunes <- request
+	return <-request.done
+}
+
+// Fork copies the data of the source at the commit into the empty data of the mutator,
+// the IDs generated afterwards come after the copied ones.
+func (m *Mutator) Fork(source *data.WithVersion, commitID uint64) error {
+	err := source.Fork(commitID, m.dataWithVersion)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	entities, err := m.dataWithVersion.EntitiesAt(commitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	for entityID := range entities {
+		err = m.entityIDGen.SkipTo(entityID)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	return m.transactionIDGen.SkipTo(commitID)
+}
+
+// Merge commits the changes made on the branch from baseCommitID to branchCommitID as a single transaction,
+// the mutator must be started.
+// Nothing is committed when there are conflicts, which are resolved by a follow-up transaction before merging again.
+func (m *Mutator) Merge(
+	branch *data.WithVersion,
+	baseCommitID uint64,
+	branchCommitID uint64,
+	metadata data.CommitMetadata,
+) (data.MergeResult, error) {
+	request := mergeRequest{
+		branch:         branch,
+		baseCommitID:   baseCommitID,
+		branchCommitID: branchCommitID,
+		done:           make(chan mergeResponse, 1),
+	}
+	err := m.enqueue(Transaction{Metadata: metadata}, &request)
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	response := <-request.done
+	return response.result, response.err
+}
+
+// Revert undoes the changes of the commit with a new transaction, the mutator must be started.
+// It returns the ID of the new transaction, 0 when there is nothing to undo,
+// and RevertConflicts when later commits changed the same attributes again.
+func (m *Mutator) Revert(commitID uint64) (uint64, error) {
+	_, found, err := m.dataWithVersion.FindCommit(commitID)
+	if err != nil {
+		log.Println(err)
+		return 0, err
+	}
+
+	if !found {
+		return 0, fmt.Errorf("commit not found: commitID=%v", commitID)
+	}
+
+	err = m.dataWithVersion.CheckRetained(commitID - 1)
+	if err != nil {
+		return 0, err
+	}
+
+	// reverting the commit merges the changes from the commit back to the data right before it
+	result, err := m.Merge(m.dataWithVersion, commitID, commitID-1, data.CommitMetadata{
+		Message: fmt.Sprintf("revert commit %v", commitID),
+	})
+	if err != nil {
+		log.Println(err)
+		return 0, err
+	}
+
+	if result.HasConflicts() {
+		return 0, RevertConflicts{
+			CommitID:        commitID,
+			EntityConflicts: result.EntityConflicts,
+			SchemaConflicts: result.SchemaConflicts,
+		}
+	}
+
+	return result.CommitID, nil
+}
+
+// merge plans the merge on the turn of its transaction, so the transactions queued before it are committed
+// and no other transaction changes the data in the meantime.
+func (m *Mutator) merge(transaction Transaction, request mergeRequest) (data.MergeResult, error) {
+	count, err := m.dataWithVersion.CountCommits()
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	var commit data.Commit
+	if count > 0 {
+		commit, err = m.dataWithVersion.GetLatestCommit()
+		if err != nil {
+			log.Println(err)
+			return data.MergeResult{}, err
+		}
+	}
+
+	plan, err := m.dataWithVersion.PlanMerge(
+		request.branch,
+		request.baseCommitID,
+		request.branchCommitID,
+		commit.CommittedTransactionID)
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	result := data.MergeResult{
+		EntityConflicts: plan.EntityConflicts,
+		SchemaConflicts: plan.SchemaConflicts,
+	}
+	if plan.HasConflicts() || len(plan.Mutations) == 0 {
+		return result, nil
+	}
+
+	// the entities created on the branch keep their IDs
+	transaction.Mutations = plan.Mutations
+	err = m.storeTransaction(transaction)
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	m.processTransaction(transaction)
+	status, err := m.transactionStatus.Get(transaction.ID)
+	if err != nil {
+		log.Println(err)
+		return data.MergeResult{}, err
+	}
+
+	if status != transactionCommitted {
+		return data.MergeResult{}, fmt.Errorf("merge aborted: transactionID=%v", transaction.ID)
+	}
+
+	result.CommitID = transaction.ID
+	return result, nil
+}
+
+func (m *Mutator) processTransaction(transaction Transaction) {
+	err := m.commitTransaction(transaction)
+	if err == nil {
+		err = m.transactionStatus.Set(transaction.ID, transactionCommitted)
+		if err != nil {
+			log.Println(err)
+		}
+	} else {
+		log.Printf("fail to commit transaction: transaction=%v error=%v\n", transaction.ID, err)
+		err = m.rollbackTransaction(transaction.ID)
+		if err != nil {
+			log.Println(err)
+		} else {
+			err = m.transactionStatus.Set(transaction.ID, transactionAborted)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	go func(transaction Transaction) {
+		m.onTransactionProcessed <- transaction.ID
+	}(transaction)
+}
+
 func (m *Mutator) commitTransaction(transaction Transaction) error {
 	log.Printf("[commitTransaction] %v\n", transaction)
 
@@ -92,7 +322,16 @@ func (m *Mutator) commitTransaction(transaction Transaction) error {
 		mutations := mutations
 		errGroup.Go(func() error {
 			for _, mutation := range mutations {
-				err = m.commitMutation(transaction.ID, mutation)
+				if transaction.recovered {
+					var err error
+					mutation, err = m.convertEntityInput(transaction.ID, mutation)
+					if err != nil {
+						log.Println(err)
+						return err
+					}
+				}
+
+				err := m.commitMutation(transaction.ID, mutation)
 				if err != nil {
 					log.Println(err)
 					return err
@@ -112,24 +351,181 @@ func (m *Mutator) commitTransaction(transaction Transaction) error {
 	commit := data.Commit{
 		CommittedTransactionID: transaction.ID,
 		CommittedAt:            time.Now(),
+		Summary:                data.NewCommitSummary(transaction.Mutations),
+		Metadata:               transaction.Metadata,
 	}
 	return m.dataWithVersion.AppendCommit(commit)
 }
 
 func (m *Mutator) rollbackTransaction(transactionID uint64) error {
-	_, err := m.dataWithVersion.EntityHistories.RemoveVersion(transactionID)
+	return m.dataWithVersion.RemoveVersion(transactionID)
+}
+
+// recover finishes the transactions interrupted by a restart. A started transaction is rolled back
+// unless its commit is recorded. It returns the transactions to commit again in order,
+// including the ones never started.
+func (m *Mutator) recover() ([]Transaction, error) {
+	finishedID, err := m.readFinishedTransactionID()
 	if err != nil {
 		log.Println(err)
-		return err
+		return nil, err
+	}
+
+	iterator := m.transactions.AscendFrom(finishedID + 1)
+	defer iterator.Close()
+
+	pending := make([]Transaction, 0)
+	for iterator.Next() {
+		transaction := iterator.Value()
+		transaction.recovered = true
+		contain, err := m.transactionStatus.Contain(transaction.ID)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if !contain {
+			pending = append(pending, transaction)
+			continue
+		}
+
+		status, err := m.transactionStatus.Get(transaction.ID)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if status != transactionStarted {
+			continue
+		}
+
+		committed, err := m.dataWithVersion.IsCommitted(transaction.ID)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if committed {
+			// only the status is lost
+			err = m.transactionStatus.Set(transaction.ID, transactionCommitted)
+			if err != nil {
+				log.Println(err)
+				return nil, err
+			}
+
+			continue
+		}
+
+		log.Printf("roll back interrupted transaction: transaction=%v\n", transaction.ID)
+		err = m.rollbackTransaction(transaction.ID)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		pending = append(pending, transaction)
 	}
 
-	_, err = m.dataWithVersion.SchemaHistories.RemoveVersion(transactionID)
+	if iterator.Err() != nil {
+		log.Println(iterator.Err())
+		return nil, iterator.Err()
+	}
+
+	return pending, nil
+}
+
+// markFinished moves the low-water mark of the finished transactions to the transaction,
+// false when the transaction is left unfinished & the mark has to stay before it.
+func (m *Mutator) markFinished(transactionID uint64) bool {
+	contain, err := m.transactionStatus.Contain(transactionID)
 	if err != nil {
 		log.Println(err)
-		return err
+		return false
 	}
 
-	return err
+	if contain {
+		status, err := m.transactionStatus.Get(transactionID)
+		if err != nil {
+			log.Println(err)
+			return false
+		}
+
+		if status == transactionStarted {
+			return false
+		}
+	} else {
+		// a merge without changes to commit is never stored
+		_, stored, err := m.transactions.Get(transactionID)
+		if err != nil {
+			log.Println(err)
+			return false
+		}
+
+		if stored {
+			return false
+		}
+	}
+
+	buf, err := json.Marshal(transactionID)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+
+	err = m.rawMap.Set(m.finishedPath, buf)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+
+	return true
+}
+
+func (m *Mutator) readFinishedTransactionID() (uint64, error) {
+	contain, err := m.rawMap.Contain(m.finishedPath)
+	if err != nil || !contain {
+		return 0, err
+	}
+
+	buf, err := m.rawMap.Get(m.finishedPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var transactionID uint64
+	err = json.Unmarshal(buf, &transactionID)
+	return transactionID, err
+}
+
+// convertEntityInput converts the attribute values of a recovered transaction back to the types of the schema.
+func (m *Mutator) convertEntityInput(transactionID uint64, mutation data.Mutation) (data.Mutation, error) {
+	if len(mutation.EntityInput.AttributesToCreateOrUpdate) == 0 {
+		return mutation, nil
+	}
+
+	schemaName := mutation.EntityInput.SchemaName
+	if mutation.Type != data.CreateEntityMutation {
+		// the mutation reports the missing entity itself
+		entity, exist, err := m.dataWithVersion.EntityHistories.FindLatestValueAt(transactionID, mutation.EntityInput.EntityID)
+		if err != nil || !exist {
+			return mutation, err
+		}
+
+		schemaName = entity.SchemaName
+	}
+
+	schema, exist, err := m.dataWithVersion.SchemaHistories.FindLatestValueAt(transactionID, schemaName)
+	if err != nil || !exist {
+		return mutation, err
+	}
+
+	attributes := make(map[string]interface{})
+	for attribute, value := range mutation.EntityInput.AttributesToCreateOrUpdate {
+		attributes[attribute] = data.ConvertValue(schema.Attributes[attribute], value)
+	}
+
+	mutation.EntityInput.AttributesToCreateOrUpdate = attributes
+	return mutation, nil
 }
 
 func (m *Mutator) commitMutation(transactionID uint64, mutation data.Mutation) error {
@@ -153,6 +549,8 @@ func (m *Mutator) commitMutation(transactionID uint64, mutation data.Mutation) e
 		return m.commitDeleteEntityAttributesMutation(transactionID, mutation)
 	case data.UpdateEntityAttributesMutation:
 		return m.commitUpdateEntityAttributesMutation(transactionID, mutation)
+	case data.RestoreEntityMutation:
+		return m.commitRestoreEntityMutation(transactionID, mutation)
 	default:
 		return fmt.Errorf("unknow mutation: %v", mutation)
 	}
@@ -247,7 +645,7 @@ func (m *Mutator) commitDeleteSchemaAttributesMutation(transactionID uint64, mut
 		return err
 	}
 
-	entities, _, err := m.dataWithVersion.EntityHistories.ListAllLatestValuesAt(transactionID)
+	entities, err := m.dataWithVersion.EntitiesAt(transactionID)
 	if err != nil {
 		log.Println(err)
 		return err
@@ -258,12 +656,24 @@ func (m *Mutator) commitDeleteSchemaAttributesMutation(transactionID uint64, mut
 			continue
 		}
 
+		// the entities may have dropped some of the attributes already
+		attributesToDelete := make([]string, 0)
+		for _, attribute := range mutation.SchemaInput.AttributesToDelete {
+			if _, exist = entity.Attributes[attribute]; exist {
+				attributesToDelete = append(attributesToDelete, attribute)
+			}
+		}
+
+		if len(attributesToDelete) == 0 {
+			continue
+		}
+
 		err := m.commitDeleteEntityAttributesMutation(transactionID, data.Mutation{
 			Type: data.DeleteEntityAttributesMutation,
 			EntityInput: data.EntityInput{
 				EntityID:           entityID,
 				SchemaName:         schemaName,
-				AttributesToDelete: mutation.SchemaInput.AttributesToDelete,
+				AttributesToDelete: attributesToDelete,
 			},
 		})
 		if err != nil {
@@ -300,14 +710,21 @@ func (m Mutator) commitCreateEntityMutation(transactionID uint64, mutation data.
 		return err
 	}
 
-	entityID, err := m.entityIDGen.NextID()
+	// only merges create entities with their IDs, which are never generated again
+	entityID := mutation.EntityInput.EntityID
+	if entityID == 0 {
+		entityID, err = m.entityIDGen.NextID()
+	} else {
+		err = m.entityIDGen.SkipTo(entityID)
+	}
+
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
 	mutation.EntityInput.EntityID = entityID
-	_, err = m.dataWithVersion.EntityHistories.AddVersion(transactionID, entityID, history.CreatedVersionStatus, mutation)
+	_, err = m.dataWithVersion.AddEntityVersion(transactionID, entityID, history.CreatedVersionStatus, mutation)
 	if err != nil {
 		log.Println(err)
 	}
@@ -329,7 +746,7 @@ func (m Mutator) commitDeleteEntityMutation(transactionID uint64, mutation data.
 		return err
 	}
 
-	_, err = m.dataWithVersion.EntityHistories.AddVersion(transactionID, entityID, history.DeletedVersionStatus, mutation)
+	_, err = m.dataWithVersion.AddEntityVersion(transactionID, entityID, history.DeletedVersionStatus, mutation)
 	if err != nil {
 		log.Println(err)
 	}
@@ -380,7 +797,7 @@ func (m Mutator) commitCreateEntityAttributesMutation(transactionID uint64, muta
 		attributes[attribute] = value
 	}
 
-	_, err = m.dataWithVersion.EntityHistories.AddVersion(transactionID, entityID, history.UpdatedVersionStatus, mutation)
+	_, err = m.dataWithVersion.AddEntityVersion(transactionID, entityID, history.UpdatedVersionStatus, mutation)
 	if err != nil {
 		log.Println(err)
 	}
@@ -403,7 +820,7 @@ func (m Mutator) commitDeleteEntityAttributesMutation(transactionID uint64, muta
 	}
 
 	attributes := make(map[string]interface{})
-	for _, attribute := range mutation.SchemaInput.AttributesToDelete {
+	for _, attribute := range mutation.EntityInput.AttributesToDelete {
 		if _, exist = entity.Attributes[attribute]; !exist {
 			err = fmt.Errorf("entity attribute not found: entity=%v, attribute=%v", entityID, attribute)
 			log.Println(err)
@@ -413,7 +830,7 @@ func (m Mutator) commitDeleteEntityAttributesMutation(transactionID uint64, muta
 		attributes[attribute] = entity.Attributes[attribute]
 	}
 
-	_, err = m.dataWithVersion.EntityHistories.AddVersion(transactionID, entityID, history.UpdatedVersionStatus, mutation)
+	_, err = m.dataWithVersion.AddEntityVersion(transactionID, entityID, history.UpdatedVersionStatus, mutation)
 	if err != nil {
 		log.Println(err)
 	}
@@ -446,7 +863,7 @@ func (m Mutator) commitUpdateEntityAttributesMutation(transactionID uint64, muta
 	}
 
 	attributes := make(map[string]interface{})
-	for attribute, value := range mutation.SchemaInput.AttributesToCreateOrUpdate {
+	for attribute, value := range mutation.EntityInput.AttributesToCreateOrUpdate {
 		if _, exist = entity.Attributes[attribute]; !exist {
 			err = fmt.Errorf("entity attribute not found: entity=%v, attribute=%v", entityID, attribute)
 			log.Println(err)
@@ -462,7 +879,76 @@ func (m Mutator) commitUpdateEntityAttributesMutation(transactionID uint64, muta
 		attributes[attribute] = value
 	}
 
-	_, err = m.dataWithVersion.EntityHistories.AddVersion(transactionID, entityID, history.UpdatedVersionStatus, mutation)
+	_, err = m.dataWithVersion.AddEntityVersion(transactionID, entityID, history.UpdatedVersionStatus, mutation)
+	if err != nil {
+		log.Println(err)
+	}
+
+	return err
+}
+
+// commitRestoreEntityMutation writes the entity at the restore commit back as a new version,
+// which undeletes the entity when it is deleted since.
+func (m Mutator) commitRestoreEntityMutation(transactionID uint64, mutation data.Mutation) error {
+	entityID := mutation.EntityInput.EntityID
+	restoreCommitID := mutation.EntityInput.RestoreCommitID
+	err := m.dataWithVersion.CheckRetained(restoreCommitID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	entity, exist, err := m.dataWithVersion.EntityHistories.FindLatestValueAt(restoreCommitID, entityID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if !exist {
+		err = fmt.Errorf("entity not found: entityID=%v, commitID=%v", entityID, restoreCommitID)
+		log.Println(err)
+		return err
+	}
+
+	// the entity must still fit its schema as of now
+	schema, exist, err := m.dataWithVersion.SchemaHistories.FindLatestValueAt(transactionID, entity.SchemaName)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if !exist {
+		err = fmt.Errorf("schema not found: name=%v", entity.SchemaName)
+		log.Println(err)
+		return err
+	}
+
+	attributes := make(map[string]interface{})
+	for attribute, value := range entity.Attributes {
+		attributes[attribute] = data.ConvertValue(schema.Attributes[attribute], value)
+	}
+
+	entity.Attributes = attributes
+	err = validateEntity(schema, entity)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	_, exist, err = m.dataWithVersion.EntityHistories.FindLatestValueAt(transactionID, entityID)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	versionStatus := history.UpdatedVersionStatus
+	if !exist {
+		versionStatus = history.CreatedVersionStatus
+	}
+
+	mutation.EntityInput.SchemaName = entity.SchemaName
+	mutation.EntityInput.AttributesToCreateOrUpdate = attributes
+	_, err = m.dataWithVersion.AddEntityVersion(transactionID, entityID, versionStatus, mutation)
 	if err != nil {
 		log.Println(err)
 	}
@@ -554,10 +1040,30 @@ func validateEntityAttribute(dataType data.Type, value interface{}) error {
 	return nil
 }
 
+// withoutEntityIDs drops the entity IDs of the entities to create, so they are generated on commit.
+func withoutEntityIDs(mutations map[string][]data.Mutation) map[string][]data.Mutation {
+	newMutations := make(map[string][]data.Mutation)
+	for schemaName, schemaMutations := range mutations {
+		newSchemaMutations := make([]data.Mutation, 0, len(schemaMutations))
+		for _, mutation := range schemaMutations {
+			if mutation.Type == data.CreateEntityMutation {
+				mutation.EntityInput.EntityID = 0
+			}
+
+			newSchemaMutations = append(newSchemaMutations, mutation)
+		}
+
+		newMutations[schemaName] = newSchemaMutations
+	}
+
+	return newMutations
+}
+
 func NewMutator(
 	storagePath string,
 	refGen *idgen.IDGen,
 	rawMap storage.RawMap,
+	format reliable.Format,
 	dataWithVersion *data.WithVersion,
 ) (*Mutator, error) {
 	entityIDGen, err := idgen.New(path.Join(storagePath, "idGens", "entity"), rawMap, idGenBufferSize)
@@ -572,24 +1078,144 @@ func NewMutator(
 		return nil, err
 	}
 
-	transactions, err := reliable.NewList[Transaction](path.Join(storagePath, "transactions"), refGen, rawMap)
+	return newMutator(storagePath, refGen, rawMap, format, dataWithVersion, entityIDGen, transactionIDGen)
+}
+
+// NewBranchMutator commits the transactions of a branch, the IDs are shared with the mutator of main,
+// so the entities & the commits of the branch can be merged back as they are.
+func NewBranchMutator(
+	mainMutator *Mutator,
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+	dataWithVersion *data.WithVersion,
+) (*Mutator, error) {
+	return newMutator(
+		storagePath,
+		refGen,
+		rawMap,
+		format,
+		dataWithVersion,
+		mainMutator.entityIDGen,
+		mainMutator.transactionIDGen)
+}
+
+func newMutator(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+	dataWithVersion *data.WithVersion,
+	entityIDGen *idgen.IDGen,
+	transactionIDGen *idgen.IDGen,
+) (*Mutator, error) {
+	transactions, err := openTransactions(storagePath, refGen, rawMap, format)
 	if err != nil {
+		log.Println(err)
 		return nil, err
 	}
 
 	transactionStatus, err := reliable.NewMap[uint64, TransactionStatus](
-		path.Join(storagePath, "transactionsStatus"), refGen, rawMap)
+		path.Join(storagePath, "transactionsStatus"), refGen, rawMap, format)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Mutator{
+	mutator := &Mutator{
 		dataWithVersion:        dataWithVersion,
 		entityIDGen:            entityIDGen,
 		transactionIDGen:       transactionIDGen,
+		rawMap:                 rawMap,
 		transactions:           transactions,
 		transactionStatus:      transactionStatus,
-		incomingTransactions:   make(chan Transaction, transactionBufferSize),
+		finishedPath:           path.Join(storagePath, "finishedTransactionID"),
+		incomingPrunes:         make(chan pruneRequest),
 		onTransactionProcessed: make(chan uint64),
-	}, nil
+		queueMut:               &sync.Mutex{},
+	}
+
+	pending, err := mutator.recover()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	// the pending transactions are committed before the ones created after the restart
+	mutator.incomingTransactions = make(chan queuedTransaction, transactionBufferSize+len(pending))
+	for _, transaction := range pending {
+		mutator.incomingTransactions <- queuedTransaction{transaction: transaction}
+	}
+
+	return mutator, nil
+}
+
+// openTransactions opens the transactions by ID, the transactions kept in a list by older versions
+// are moved over in one batch, so a crash never leaves them partially migrated.
+func openTransactions(
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format reliable.Format,
+) (reliable.BTree[uint64, Transaction], error) {
+	treePath := path.Join(storagePath, "transactionsByID")
+	listPath := path.Join(storagePath, "transactions")
+	contain, err := rawMap.Contain(treePath)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[uint64, Transaction]{}, err
+	}
+
+	listContain, err := rawMap.Contain(listPath)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[uint64, Transaction]{}, err
+	}
+
+	if contain || !listContain {
+		return reliable.NewBTree[uint64, Transaction](treePath, refGen, rawMap, format)
+	}
+
+	list, err := reliable.NewList[Transaction](listPath, refGen, rawMap, format)
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[uint64, Transaction]{}, err
+	}
+
+	items, err := list.Items()
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[uint64, Transaction]{}, err
+	}
+
+	entries := make([]reliable.BTreeEntry[uint64, Transaction], 0, len(items))
+	for _, transaction := range items {
+		entries = append(entries, reliable.BTreeEntry[uint64, Transaction]{Key: transaction.ID, Value: transaction})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	err = storage.RunInBatch(rawMap, func(batch storage.RawMap) error {
+		transactions, err := reliable.NewBTree[uint64, Transaction](treePath, refGen, batch, format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = transactions.BulkLoad(entries)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return batch.Delete(listPath)
+	})
+	if err != nil {
+		log.Println(err)
+		return reliable.BTree[uint64, Transaction]{}, err
+	}
+
+	return reliable.NewBTree[uint64, Transaction](treePath, refGen, rawMap, format)
 }
@@ -0,0 +1,1606 @@
+package mutation
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"tstore/data"
+	"tstore/history"
+	"tstore/idgen"
+	"tstore/query"
+	"tstore/query/lang"
+	"tstore/reliable"
+	"tstore/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const workloadSize = 12
+const testSnapshotInterval = 3
+
+func TestMutator_Faults(t *testing.T) {
+	operations := runWorkload(t, func(int, storage.Operation, string) storage.Fault {
+		return storage.Fault{}
+	})
+
+	testCases := []struct {
+		name string
+		plan storage.FaultPlan
+	}{
+		{name: "FailWrites", plan: storage.FailWritesPlan(operations/7, operations/3, operations/2, operations*4/5)},
+		{name: "Delay", plan: storage.ScriptedFaultPlan(map[int]storage.Fault{
+			operations / 4: {Delay: 10 * time.Millisecond},
+			operations / 2: {Delay: 10 * time.Millisecond},
+		})},
+		{name: "CrashEarly", plan: storage.CrashPlan(operations / 10)},
+		{name: "CrashMiddle", plan: storage.CrashPlan(operations / 2)},
+		{name: "CrashLate", plan: storage.CrashPlan(operations * 9 / 10)},
+	}
+	for seed := int64(1); seed <= 5; seed++ {
+		testCases = append(testCases, struct {
+			name string
+			plan storage.FaultPlan
+		}{
+			name: fmt.Sprintf("Random%v", seed),
+			plan: storage.RandomFaultPlan(seed, 0.01, 0),
+		})
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			runWorkload(t, testCase.plan)
+		})
+	}
+}
+
+func TestMutator_Recovery(t *testing.T) {
+	operations := runRecovery(t, func(int, storage.Operation, string) storage.Fault {
+		return storage.Fault{}
+	})
+
+	for crashAt := 0; crashAt < operations; crashAt += operations/20 + 1 {
+		t.Run(fmt.Sprintf("CrashAt%v", crashAt), func(t *testing.T) {
+			runRecovery(t, storage.CrashPlan(crashAt))
+		})
+	}
+}
+
+func TestMutator_RecoveryMark(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	var lastID uint64
+	for index := 0; index < 3; index++ {
+		assert.Nil(t, mutator.CreateTransaction(newWorkloadTransaction(index)))
+		lastID = <-mutator.onTransactionProcessed
+	}
+
+	mutator = openMutator(t, rawMap)
+	finishedID, err := mutator.readFinishedTransactionID()
+	assert.Nil(t, err)
+	assert.Equal(t, lastID, finishedID)
+
+	// the recovery starts after the mark, the transactions before it are never visited again
+	assert.Nil(t, mutator.transactionStatus.Delete(lastID))
+	mutator = openMutator(t, rawMap)
+	assert.Equal(t, 0, len(mutator.incomingTransactions))
+}
+
+func TestMutator_MigrateTransactionList(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 5)
+	assert.Nil(t, err)
+
+	transactionIDGen, err := idgen.New(path.Join("database", "idGens", "transaction"), rawMap, idGenBufferSize)
+	assert.Nil(t, err)
+	transactionID, err := transactionIDGen.NextID()
+	assert.Nil(t, err)
+
+	// older versions queue the transactions in a list
+	list, err := reliable.NewList[Transaction](path.Join("database", "transactions"), refGen, rawMap, reliable.JSONFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, list.Append(Transaction{
+		ID:        transactionID,
+		Mutations: newWorkloadTransaction(0).Mutations,
+	}))
+
+	mutator := openMutator(t, rawMap)
+	contain, err := rawMap.Contain(path.Join("database", "transactions"))
+	assert.Nil(t, err)
+	assert.False(t, contain)
+
+	transactions := storedTransactions(t, mutator)
+	assert.Equal(t, 1, len(transactions))
+	assert.Equal(t, transactionID, transactions[0].ID)
+
+	mutator.Start()
+	assert.Equal(t, transactionID, <-mutator.onTransactionProcessed)
+	assertCommittedTransaction(t, mutator, transactionID)
+	assertCommitted(t, mutator, 0)
+}
+
+func TestMutator_Snapshots(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	commit := func(mutations ...data.Mutation) uint64 {
+		assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+			Mutations: map[string][]data.Mutation{"user": mutations},
+		}))
+		return <-mutator.onTransactionProcessed
+	}
+
+	createSchemaID := commit(data.Mutation{
+		Type: data.CreateSchemaMutation,
+		SchemaInput: data.SchemaInput{
+			Name:                       "user",
+			AttributesToCreateOrUpdate: map[string]data.Type{"age": data.IntDataType, "name": data.StringDataType},
+		},
+	})
+
+	commitIDs := []uint64{createSchemaID}
+	for index := 0; index < 10; index++ {
+		commitIDs = append(commitIDs, commit(data.Mutation{
+			Type: data.CreateEntityMutation,
+			EntityInput: data.EntityInput{
+				SchemaName:                 "user",
+				AttributesToCreateOrUpdate: map[string]interface{}{"age": index},
+			},
+		}))
+	}
+
+	entities, err := mutator.dataWithVersion.EntitiesAt(math.MaxUint64)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(entities))
+
+	for entityID := range entities {
+		if entityID%3 == 0 {
+			commitIDs = append(commitIDs, commit(data.Mutation{
+				Type:        data.DeleteEntityMutation,
+				EntityInput: data.EntityInput{EntityID: entityID},
+			}))
+			continue
+		}
+
+		commitIDs = append(commitIDs, commit(data.Mutation{
+			Type: data.CreateEntityAttributesMutation,
+			EntityInput: data.EntityInput{
+				EntityID:                   entityID,
+				AttributesToCreateOrUpdate: map[string]interface{}{"name": fmt.Sprint(entityID)},
+			},
+		}))
+	}
+
+	// the changes recorded by the aborted transaction are rolled back
+	abortedID := commit(
+		data.Mutation{
+			Type: data.CreateEntityMutation,
+			EntityInput: data.EntityInput{
+				SchemaName:                 "user",
+				AttributesToCreateOrUpdate: map[string]interface{}{"age": 100},
+			},
+		},
+		data.Mutation{
+			Type:        data.DeleteEntityMutation,
+			EntityInput: data.EntityInput{EntityID: math.MaxUint64},
+		})
+	status, err := mutator.transactionStatus.Get(abortedID)
+	assert.Nil(t, err)
+	assert.Equal(t, transactionAborted, status)
+
+	// the commit fails to be recorded after the entities are snapshotted at it
+	for (len(commitIDs)+1)%testSnapshotInterval != 0 {
+		commitIDs = append(commitIDs, commit())
+	}
+
+	mutator = openMutator(t, failAppendCommit(rawMap))
+	mutator.Start()
+	snapshotAbortedID := commit(data.Mutation{
+		Type: data.CreateEntityMutation,
+		EntityInput: data.EntityInput{
+			SchemaName:                 "user",
+			AttributesToCreateOrUpdate: map[string]interface{}{"age": 200},
+		},
+	})
+	status, err = mutator.transactionStatus.Get(snapshotAbortedID)
+	assert.Nil(t, err)
+	assert.Equal(t, transactionAborted, status)
+
+	mutator = openMutator(t, rawMap)
+	for _, commitID := range append(commitIDs, abortedID, snapshotAbortedID, math.MaxUint64) {
+		assertSnapshots(t, mutator, commitID)
+	}
+}
+
+func TestMutator_Prune(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	commit := func(mutations ...data.Mutation) uint64 {
+		assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+			Mutations: map[string][]data.Mutation{"user": mutations},
+		}))
+		transactionID := <-mutator.onTransactionProcessed
+		status, err := mutator.transactionStatus.Get(transactionID)
+		assert.Nil(t, err)
+		assert.Equal(t, transactionCommitted, status)
+		return transactionID
+	}
+
+	commitIDs := []uint64{commit(data.Mutation{
+		Type: data.CreateSchemaMutation,
+		SchemaInput: data.SchemaInput{
+			Name:                       "user",
+			AttributesToCreateOrUpdate: map[string]data.Type{"age": data.IntDataType},
+		},
+	})}
+	entityIDs := make([]uint64, 0)
+	for index := 0; index < 4; index++ {
+		commitIDs = append(commitIDs, commit(data.Mutation{
+			Type: data.CreateEntityMutation,
+			EntityInput: data.EntityInput{
+				SchemaName:                 "user",
+				AttributesToCreateOrUpdate: map[string]interface{}{"age": index},
+			},
+		}))
+
+		entities, err := mutator.dataWithVersion.EntitiesAt(math.MaxUint64)
+		assert.Nil(t, err)
+		for entityID := range entities {
+			if len(entityIDs) == 0 || entityID > entityIDs[len(entityIDs)-1] {
+				entityIDs = append(entityIDs, entityID)
+			}
+		}
+	}
+
+	commitIDs = append(commitIDs, commit(data.Mutation{
+		Type:        data.DeleteEntityMutation,
+		EntityInput: data.EntityInput{EntityID: entityIDs[0]},
+	}))
+
+	for round := 0; round < 3; round++ {
+		for _, entityID := range entityIDs[1:] {
+			commitIDs = append(commitIDs, commit(data.Mutation{
+				Type: data.UpdateEntityAttributesMutation,
+				EntityInput: data.EntityInput{
+					EntityID:                   entityID,
+					AttributesToCreateOrUpdate: map[string]interface{}{"age": round * 10},
+				},
+			}))
+		}
+	}
+
+	expected := make(map[uint64]map[uint64]data.Entity)
+	for _, commitID := range commitIDs {
+		entities, err := mutator.dataWithVersion.EntitiesAt(commitID)
+		assert.Nil(t, err)
+		expected[commitID] = entities
+	}
+
+	keepCommits := 5
+	assert.Nil(t, mutator.Prune(data.RetentionPolicy{KeepCommits: keepCommits}))
+	// relaxing the policy does not bring the pruned versions back
+	assert.Nil(t, mutator.Prune(data.RetentionPolicy{}))
+
+	mutator = openMutator(t, rawMap)
+	horizon := len(commitIDs) - keepCommits
+	for index, commitID := range commitIDs {
+		entities, err := mutator.dataWithVersion.EntitiesAt(commitID)
+		if index < horizon {
+			var commitPruned data.CommitPruned
+			assert.ErrorAs(t, err, &commitPruned)
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected[commitID], entities)
+		assertSnapshots(t, mutator, commitID)
+	}
+
+	// no commit is within the duration, only the latest one is kept
+	assert.Nil(t, mutator.dataWithVersion.Prune(data.RetentionPolicy{KeepDuration: time.Hour}, time.Now().Add(2*time.Hour)))
+	latestCommitID := commitIDs[len(commitIDs)-1]
+	_, err := mutator.dataWithVersion.EntitiesAt(latestCommitID - 1)
+	var commitPruned data.CommitPruned
+	assert.ErrorAs(t, err, &commitPruned)
+
+	entities, err := mutator.dataWithVersion.EntitiesAt(latestCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected[latestCommitID], entities)
+	assertSnapshots(t, mutator, latestCommitID)
+
+	_, exist, err := mutator.dataWithVersion.EntityHistories.FindLatestValueAt(math.MaxUint64, entityIDs[0])
+	assert.Nil(t, err)
+	assert.False(t, exist)
+
+	contain, err := rawMap.Contain(path.Join("database", "entityHistories", "histories", fmt.Sprint(entityIDs[0])))
+	assert.Nil(t, err)
+	assert.False(t, contain, "deleted entity is not pruned")
+}
+
+func TestMutator_BlameEntity(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	commit := func(mutation data.Mutation) uint64 {
+		assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+			Mutations: map[string][]data.Mutation{"user": {mutation}},
+		}))
+		return <-mutator.onTransactionProcessed
+	}
+
+	commit(data.Mutation{
+		Type: data.CreateSchemaMutation,
+		SchemaInput: data.SchemaInput{
+			Name: "user",
+			AttributesToCreateOrUpdate: map[string]data.Type{
+				"firstName": data.StringDataType,
+				"lastName":  data.StringDataType,
+			},
+		},
+	})
+	createID := commit(data.Mutation{
+		Type: data.CreateEntityMutation,
+		EntityInput: data.EntityInput{
+			SchemaName:                 "user",
+			AttributesToCreateOrUpdate: map[string]interface{}{"firstName": "Harry", "lastName": "Potter"},
+		},
+	})
+
+	entities, err := mutator.dataWithVersion.EntitiesAt(math.MaxUint64)
+	assert.Nil(t, err)
+	var entityID uint64
+	for id := range entities {
+		entityID = id
+	}
+
+	updateID := commit(data.Mutation{
+		Type: data.UpdateEntityAttributesMutation,
+		EntityInput: data.EntityInput{
+			EntityID:                   entityID,
+			AttributesToCreateOrUpdate: map[string]interface{}{"lastName": "What"},
+		},
+	})
+
+	// commits written before the commit index existed
+	assert.Nil(t, rawMap.Delete(path.Join("database", "commitIndex")))
+	mutator = openMutator(t, rawMap)
+
+	createCommit, found, err := mutator.dataWithVersion.FindCommit(createID)
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	updateCommit, found, err := mutator.dataWithVersion.FindCommit(updateID)
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	blame, found, err := mutator.dataWithVersion.BlameEntity(updateID, entityID)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, data.EntityBlame{
+		EntityID:   entityID,
+		SchemaName: "user",
+		Attributes: []data.AttributeBlame{
+			{
+				Attribute:   "firstName",
+				Value:       "Harry",
+				CommitID:    createID,
+				CommittedAt: createCommit.CommittedAt,
+				Status:      history.CreatedVersionStatus,
+			},
+			{
+				Attribute:   "lastName",
+				Value:       "What",
+				CommitID:    updateID,
+				CommittedAt: updateCommit.CommittedAt,
+				Status:      history.UpdatedVersionStatus,
+			},
+		},
+	}, blame)
+
+	blame, found, err = mutator.dataWithVersion.BlameEntity(createID, entityID)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "Potter", blame.Attributes[1].Value)
+	assert.Equal(t, createID, blame.Attributes[1].CommitID)
+
+	_, found, err = mutator.dataWithVersion.BlameEntity(createID-1, entityID)
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestMutator_DiffCommits(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	commit := func(mutation data.Mutation) uint64 {
+		assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+			Mutations: map[string][]data.Mutation{"user": {mutation}},
+		}))
+		return <-mutator.onTransactionProcessed
+	}
+	createEntity := func(firstName string) uint64 {
+		commitID := commit(data.Mutation{
+			Type: data.CreateEntityMutation,
+			EntityInput: data.EntityInput{
+				SchemaName:                 "user",
+				AttributesToCreateOrUpdate: map[string]interface{}{"firstName": firstName, "lastName": "Potter"},
+			},
+		})
+
+		entities := findEntities(t, mutator, commitID, "user")
+		for _, entity := range entities {
+			if entity.Attributes["firstName"] == firstName {
+				return entity.ID
+			}
+		}
+
+		t.Fatalf("entity not created: firstName=%v", firstName)
+		return 0
+	}
+
+	commit(data.Mutation{
+		Type: data.CreateSchemaMutation,
+		SchemaInput: data.SchemaInput{
+			Name: "user",
+			AttributesToCreateOrUpdate: map[string]data.Type{
+				"firstName": data.StringDataType,
+				"lastName":  data.StringDataType,
+			},
+		},
+	})
+	harryID := createEntity("Harry")
+	lilyID := createEntity("Lily")
+	fromID := commit(data.Mutation{
+		Type: data.CreateSchemaAttributesMutation,
+		SchemaInput: data.SchemaInput{
+			Name:                       "user",
+			AttributesToCreateOrUpdate: map[string]data.Type{"house": data.StringDataType},
+		},
+	})
+
+	commit(data.Mutation{
+		Type: data.UpdateEntityAttributesMutation,
+		EntityInput: data.EntityInput{
+			EntityID:                   harryID,
+			AttributesToCreateOrUpdate: map[string]interface{}{"lastName": "What"},
+		},
+	})
+	commit(data.Mutation{
+		Type:        data.DeleteEntityMutation,
+		EntityInput: data.EntityInput{EntityID: lilyID},
+	})
+	jamesID := createEntity("James")
+	// changes reverted within the commits are not part of the diff
+	commit(data.Mutation{
+		Type: data.UpdateEntityAttributesMutation,
+		EntityInput: data.EntityInput{
+			EntityID:                   jamesID,
+			AttributesToCreateOrUpdate: map[string]interface{}{"lastName": "Evans"},
+		},
+	})
+	toID := commit(data.Mutation{
+		Type: data.UpdateEntityAttributesMutation,
+		EntityInput: data.EntityInput{
+			EntityID:                   jamesID,
+			AttributesToCreateOrUpdate: map[string]interface{}{"lastName": "Potter"},
+		},
+	})
+
+	expected := data.CommitDiff{
+		Entities: []data.EntityDiff{
+			{
+				EntityID:   harryID,
+				SchemaName: "user",
+				Status:     history.UpdatedVersionStatus,
+				Attributes: []data.AttributeDiff{
+					{
+						Attribute: "lastName",
+						OldValue:  "Potter",
+						NewValue:  "What",
+						Status:    history.UpdatedVersionStatus,
+					},
+				},
+			},
+			{
+				EntityID:   lilyID,
+				SchemaName: "user",
+				Status:     history.DeletedVersionStatus,
+				Attributes: []data.AttributeDiff{
+					{Attribute: "firstName", OldValue: "Lily", Status: history.DeletedVersionStatus},
+					{Attribute: "lastName", OldValue: "Potter", Status: history.DeletedVersionStatus},
+				},
+			},
+			{
+				EntityID:   jamesID,
+				SchemaName: "user",
+				Status:     history.CreatedVersionStatus,
+				Attributes: []data.AttributeDiff{
+					{Attribute: "firstName", NewValue: "James", Status: history.CreatedVersionStatus},
+					{Attribute: "lastName", NewValue: "Potter", Status: history.CreatedVersionStatus},
+				},
+			},
+		},
+		Schemas: []data.SchemaDiff{},
+	}
+
+	executor := query.NewExecutor(mutator.dataWithVersion)
+	diff, err := executor.DiffCommits(fromID, toID, lang.Expression(lang.All))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, diff)
+
+	diff, err = executor.DiffCommits(fromID, toID, lang.Expression(lang.EqualTo("lastName", "What")))
+	assert.Nil(t, err)
+	assert.Equal(t, expected.Entities[:1], diff.Entities)
+
+	diff, err = executor.DiffCommits(0, fromID, lang.Expression(lang.All))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(diff.Entities))
+	assert.Equal(t, []data.SchemaDiff{
+		{
+			SchemaName: "user",
+			Status:     history.CreatedVersionStatus,
+			Attributes: []data.SchemaAttributeDiff{
+				{Attribute: "firstName", OldType: data.NoneDataType, NewType: data.StringDataType, Status: history.CreatedVersionStatus},
+				{Attribute: "house", OldType: data.NoneDataType, NewType: data.StringDataType, Status: history.CreatedVersionStatus},
+				{Attribute: "lastName", OldType: data.NoneDataType, NewType: data.StringDataType, Status: history.CreatedVersionStatus},
+			},
+		},
+	}, diff.Schemas)
+
+	// commits made before snapshots existed have no recorded changes
+	assert.Nil(t, rawMap.Delete(path.Join("database", "snapshots")))
+	mutator = openMutator(t, rawMap)
+	executor = query.NewExecutor(mutator.dataWithVersion)
+	diff, err = executor.DiffCommits(fromID, toID, lang.Expression(lang.All))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, diff)
+}
+
+func TestMutator_CommitAt(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	commit := func() data.Commit {
+		assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+			Mutations: map[string][]data.Mutation{"user": {}},
+		}))
+		commit, found, err := mutator.dataWithVersion.FindCommit(<-mutator.onTransactionProcessed)
+		assert.Nil(t, err)
+		assert.True(t, found)
+		return commit
+	}
+
+	commits := make([]data.Commit, 0)
+	for index := 0; index < 5; index++ {
+		commits = append(commits, commit())
+	}
+
+	// the commit fails to be recorded
+	mutator = openMutator(t, failAppendCommit(rawMap))
+	mutator.Start()
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{"user": {}},
+	}))
+	<-mutator.onTransactionProcessed
+
+	assertCommitAt := func(mutator *Mutator) {
+		_, found, err := mutator.dataWithVersion.CommitAt(commits[0].CommittedAt.Add(-time.Nanosecond))
+		assert.Nil(t, err)
+		assert.False(t, found)
+
+		for _, commit := range commits {
+			found, _, err := mutator.dataWithVersion.CommitAt(commit.CommittedAt)
+			assert.Nil(t, err)
+			assert.Equal(t, commit.CommittedTransactionID, found.CommittedTransactionID)
+			assert.True(t, commit.CommittedAt.Equal(found.CommittedAt))
+		}
+
+		latest, found, err := mutator.dataWithVersion.CommitAt(time.Now().Add(time.Hour))
+		assert.Nil(t, err)
+		assert.True(t, found)
+		assert.Equal(t, commits[len(commits)-1].CommittedTransactionID, latest.CommittedTransactionID)
+	}
+
+	assertCommitAt(openMutator(t, rawMap))
+
+	// commits written before the time index existed
+	assert.Nil(t, rawMap.Delete(path.Join("database", "commitTimeIndex")))
+	assertCommitAt(openMutator(t, rawMap))
+
+	// a commit made before the latest one after a clock step is recorded right after it
+	mutator = openMutator(t, rawMap)
+	latest := commits[len(commits)-1]
+	steppedID := latest.CommittedTransactionID + 100
+	assert.Nil(t, mutator.dataWithVersion.AppendCommit(data.Commit{
+		CommittedTransactionID: steppedID,
+		CommittedAt:            commits[0].CommittedAt,
+	}))
+
+	stepped, found, err := mutator.dataWithVersion.FindCommit(steppedID)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.True(t, stepped.CommittedAt.Equal(latest.CommittedAt.Add(time.Nanosecond)))
+
+	for _, commit := range append(commits, stepped) {
+		found, _, err := mutator.dataWithVersion.CommitAt(commit.CommittedAt)
+		assert.Nil(t, err)
+		assert.Equal(t, commit.CommittedTransactionID, found.CommittedTransactionID)
+	}
+}
+
+func TestMutator_ListCommits(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	commits := make([]data.Commit, 0)
+	for index := 0; index < 7; index++ {
+		// the workload transaction aborts when index%4 == 3
+		assert.Nil(t, mutator.CreateTransaction(newWorkloadTransaction(index*4)))
+		commit, found, err := mutator.dataWithVersion.FindCommit(<-mutator.onTransactionProcessed)
+		assert.Nil(t, err)
+		assert.True(t, found)
+		commits = append(commits, commit)
+	}
+
+	assert.Equal(t, data.CommitSummary{
+		MutationCounts: map[data.MutationType]int{data.CreateSchemaMutation: 1, data.CreateEntityMutation: 1},
+		Schemas:        []string{"schema0"},
+	}, commits[0].Summary)
+
+	listCommits := func(query data.CommitLogQuery) []data.Commit {
+		listed := make([]data.Commit, 0)
+		for {
+			page, err := mutator.dataWithVersion.ListCommits(query)
+			assert.Nil(t, err)
+			assert.LessOrEqual(t, len(page.Commits), query.PageSize)
+			listed = append(listed, page.Commits...)
+			if page.NextPageToken == 0 {
+				return listed
+			}
+
+			query.PageToken = page.NextPageToken
+		}
+	}
+	reversed := func(commits []data.Commit) []data.Commit {
+		reversedCommits := make([]data.Commit, 0, len(commits))
+		for index := len(commits) - 1; index >= 0; index-- {
+			reversedCommits = append(reversedCommits, commits[index])
+		}
+
+		return reversedCommits
+	}
+
+	for _, pageSize := range []int{1, 3, 7, 10} {
+		assert.Equal(t, commits, listCommits(data.CommitLogQuery{PageSize: pageSize}))
+		assert.Equal(t, reversed(commits), listCommits(data.CommitLogQuery{PageSize: pageSize, NewestFirst: true}))
+		assert.Equal(t, commits[2:5], listCommits(data.CommitLogQuery{
+			BeginCommitID: commits[2].CommittedTransactionID,
+			EndCommitID:   commits[4].CommittedTransactionID,
+			PageSize:      pageSize,
+		}))
+		assert.Equal(t, reversed(commits[1:6]), listCommits(data.CommitLogQuery{
+			BeginTime:   commits[1].CommittedAt,
+			EndTime:     commits[5].CommittedAt,
+			NewestFirst: true,
+			PageSize:    pageSize,
+		}))
+	}
+
+	assert.Equal(t, []data.Commit{}, listCommits(data.CommitLogQuery{
+		EndTime:  commits[0].CommittedAt.Add(-time.Nanosecond),
+		PageSize: 3,
+	}))
+}
+
+func TestMutator_CommitMetadata(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	metadata := data.CommitMetadata{
+		Author:  "harry",
+		Message: "create the users",
+		Labels:  map[string]string{"ticket": "HP-7"},
+	}
+	input := newWorkloadTransaction(0)
+	input.Metadata = metadata
+	assert.Nil(t, mutator.CreateTransaction(input))
+	commitID := <-mutator.onTransactionProcessed
+
+	assert.Nil(t, mutator.CreateTransaction(newWorkloadTransaction(1)))
+	latestID := <-mutator.onTransactionProcessed
+
+	assert.Nil(t, mutator.dataWithVersion.CreateTag("release-2026-10", commitID))
+	assert.Nil(t, mutator.dataWithVersion.CreateTag("latest", latestID))
+
+	// tags can't be moved
+	err := mutator.dataWithVersion.CreateTag("release-2026-10", latestID)
+	assert.Equal(t, data.TagExists("name=release-2026-10"), err)
+
+	assert.NotNil(t, mutator.dataWithVersion.CreateTag("aborted", latestID+1))
+	assert.NotNil(t, mutator.dataWithVersion.CreateTag("release/2026-10", commitID))
+
+	mutator = openMutator(t, rawMap)
+	commit, found, err := mutator.dataWithVersion.FindCommit(commitID)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, metadata, commit.Metadata)
+
+	page, err := mutator.dataWithVersion.ListCommits(data.CommitLogQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, metadata, page.Commits[0].Metadata)
+
+	taggedID, err := mutator.dataWithVersion.ResolveTag("release-2026-10")
+	assert.Nil(t, err)
+	assert.Equal(t, commitID, taggedID)
+
+	_, err = mutator.dataWithVersion.ResolveTag("release-2026-11")
+	assert.Equal(t, data.TagNotFound("name=release-2026-11"), err)
+
+	resolvedID, err := mutator.dataWithVersion.ResolveCommitRef(data.TagRef("latest"))
+	assert.Nil(t, err)
+	assert.Equal(t, latestID, resolvedID)
+
+	resolvedID, err = mutator.dataWithVersion.ResolveCommitRef(data.CommitIDRef(commitID))
+	assert.Nil(t, err)
+	assert.Equal(t, commitID, resolvedID)
+
+	// the tags bound the commit log in place of the commit IDs
+	page, err = mutator.dataWithVersion.ListCommits(data.CommitLogQuery{BeginTag: "latest", EndTag: "latest"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(page.Commits))
+	assert.Equal(t, latestID, page.Commits[0].CommittedTransactionID)
+
+	_, err = mutator.dataWithVersion.ListCommits(data.CommitLogQuery{EndTag: "release-2026-11"})
+	assert.Equal(t, data.TagNotFound("name=release-2026-11"), err)
+
+	tags, err := mutator.dataWithVersion.ListTags()
+	assert.Nil(t, err)
+	assert.Equal(t, []data.Tag{
+		{Name: "latest", CommitID: latestID},
+		{Name: "release-2026-10", CommitID: commitID},
+	}, tags)
+}
+
+func TestMutator_MergeBranch(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.CreateSchemaMutation,
+					SchemaInput: data.SchemaInput{
+						Name: "users",
+						AttributesToCreateOrUpdate: map[string]data.Type{
+							"name": data.StringDataType,
+							"age":  data.IntDataType,
+						},
+					},
+				},
+				newUserMutation("harry"),
+				newUserMutation("ron"),
+				newUserMutation("hermione"),
+			},
+		},
+	}))
+	baseCommitID := <-mutator.onTransactionProcessed
+	userIDs := findUserIDs(t, mutator, baseCommitID)
+
+	branch := openBranchMutator(t, rawMap, mutator, baseCommitID)
+	branch.Start()
+	assert.Equal(t, userIDs, findUserIDs(t, branch, baseCommitID))
+
+	assert.Nil(t, branch.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.CreateSchemaAttributesMutation,
+					SchemaInput: data.SchemaInput{
+						Name:                       "users",
+						AttributesToCreateOrUpdate: map[string]data.Type{"house": data.StringDataType},
+					},
+				},
+				{
+					Type: data.UpdateEntityAttributesMutation,
+					EntityInput: data.EntityInput{
+						EntityID:                   userIDs["harry"],
+						AttributesToCreateOrUpdate: map[string]interface{}{"age": 18},
+					},
+				},
+				{
+					Type: data.CreateEntityAttributesMutation,
+					EntityInput: data.EntityInput{
+						EntityID:                   userIDs["harry"],
+						AttributesToCreateOrUpdate: map[string]interface{}{"house": "gryffindor"},
+					},
+				},
+				{
+					Type:        data.DeleteEntityMutation,
+					EntityInput: data.EntityInput{EntityID: userIDs["ron"]},
+				},
+				newUserMutation("neville"),
+			},
+			"houses": {
+				{
+					Type: data.CreateSchemaMutation,
+					SchemaInput: data.SchemaInput{
+						Name:                       "houses",
+						AttributesToCreateOrUpdate: map[string]data.Type{"name": data.StringDataType},
+					},
+				},
+			},
+		},
+	}))
+	branchCommitID := <-branch.onTransactionProcessed
+	assertCommittedTransaction(t, branch, branchCommitID)
+	branchUserIDs := findUserIDs(t, branch, branchCommitID)
+
+	assert.Nil(t, mutator.CreateTransaction(newUserUpdate(userIDs["hermione"], 18)))
+	mainCommitID := <-mutator.onTransactionProcessed
+	assertCommittedTransaction(t, mutator, mainCommitID)
+
+	// the branch doesn't affect main until it is merged
+	harry, _, err := mutator.dataWithVersion.EntityHistories.FindLatestValueAt(mainCommitID, userIDs["harry"])
+	assert.Nil(t, err)
+	assert.Equal(t, float64(17), harry.Attributes["age"])
+
+	result, err := mutator.Merge(branch.dataWithVersion, baseCommitID, branchCommitID, data.CommitMetadata{Message: "merge feature"})
+	assert.Nil(t, err)
+	assert.False(t, result.HasConflicts())
+	assert.Equal(t, result.CommitID, <-mutator.onTransactionProcessed)
+
+	mergedUsers, err := mutator.dataWithVersion.EntitiesAt(result.CommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[uint64]data.Entity{
+		userIDs["harry"]: {
+			ID:         userIDs["harry"],
+			SchemaName: "users",
+			Attributes: map[string]interface{}{"name": "harry", "age": float64(18), "house": "gryffindor"},
+		},
+		userIDs["hermione"]: {
+			ID:         userIDs["hermione"],
+			SchemaName: "users",
+			Attributes: map[string]interface{}{"name": "hermione", "age": float64(18)},
+		},
+		branchUserIDs["neville"]: {
+			ID:         branchUserIDs["neville"],
+			SchemaName: "users",
+			Attributes: map[string]interface{}{"name": "neville", "age": float64(17)},
+		},
+	}, mergedUsers)
+
+	houses, exist, err := mutator.dataWithVersion.SchemaHistories.FindLatestValueAt(result.CommitID, "houses")
+	assert.Nil(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, map[string]data.Type{"name": data.StringDataType}, houses.Attributes)
+
+	commit, _, err := mutator.dataWithVersion.FindCommit(result.CommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, "merge feature", commit.Metadata.Message)
+
+	// both sides change harry's age, while main deletes neville which the branch changes
+	assert.Nil(t, branch.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				newUserUpdate(userIDs["harry"], 19).Mutations["users"][0],
+				newUserUpdate(branchUserIDs["neville"], 18).Mutations["users"][0],
+			},
+		},
+	}))
+	conflictCommitID := <-branch.onTransactionProcessed
+	assertCommittedTransaction(t, branch, conflictCommitID)
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				newUserUpdate(userIDs["harry"], 20).Mutations["users"][0],
+				{
+					Type:        data.DeleteEntityMutation,
+					EntityInput: data.EntityInput{EntityID: branchUserIDs["neville"]},
+				},
+			},
+		},
+	}))
+	mainCommitID = <-mutator.onTransactionProcessed
+	assertCommittedTransaction(t, mutator, mainCommitID)
+
+	result, err = mutator.Merge(branch.dataWithVersion, branchCommitID, conflictCommitID, data.CommitMetadata{})
+	assert.Nil(t, err)
+	assert.Equal(t, data.MergeResult{
+		EntityConflicts: []data.EntityConflict{
+			{
+				EntityID:     userIDs["harry"],
+				SchemaName:   "users",
+				Attribute:    "age",
+				BaseValue:    float64(18),
+				MainValue:    float64(20),
+				BranchValue:  float64(19),
+				MainStatus:   history.UpdatedVersionStatus,
+				BranchStatus: history.UpdatedVersionStatus,
+			},
+			{
+				EntityID:     branchUserIDs["neville"],
+				SchemaName:   "users",
+				MainStatus:   history.DeletedVersionStatus,
+				BranchStatus: history.UpdatedVersionStatus,
+			},
+		},
+		SchemaConflicts: []data.SchemaConflict{},
+	}, result)
+
+	latestCommit, err := mutator.dataWithVersion.GetLatestCommit()
+	assert.Nil(t, err)
+	assert.Equal(t, mainCommitID, latestCommit.CommittedTransactionID)
+
+	// the branch resolves the conflicts by agreeing with main
+	assert.Nil(t, branch.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				newUserUpdate(userIDs["harry"], 20).Mutations["users"][0],
+				{
+					Type:        data.DeleteEntityMutation,
+					EntityInput: data.EntityInput{EntityID: branchUserIDs["neville"]},
+				},
+			},
+		},
+	}))
+	resolvedCommitID := <-branch.onTransactionProcessed
+	assertCommittedTransaction(t, branch, resolvedCommitID)
+
+	result, err = mutator.Merge(branch.dataWithVersion, branchCommitID, resolvedCommitID, data.CommitMetadata{})
+	assert.Nil(t, err)
+	assert.False(t, result.HasConflicts())
+	assert.Equal(t, uint64(0), result.CommitID)
+}
+
+func TestMutator_Revert(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.CreateSchemaMutation,
+					SchemaInput: data.SchemaInput{
+						Name: "users",
+						AttributesToCreateOrUpdate: map[string]data.Type{
+							"name": data.StringDataType,
+							"age":  data.IntDataType,
+						},
+					},
+				},
+				newUserMutation("harry"),
+				newUserMutation("ron"),
+			},
+		},
+	}))
+	createCommitID := <-mutator.onTransactionProcessed
+	userIDs := findUserIDs(t, mutator, createCommitID)
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.CreateSchemaAttributesMutation,
+					SchemaInput: data.SchemaInput{
+						Name:                       "users",
+						AttributesToCreateOrUpdate: map[string]data.Type{"house": data.StringDataType},
+					},
+				},
+				newUserUpdate(userIDs["harry"], 18).Mutations["users"][0],
+				{
+					Type: data.CreateEntityAttributesMutation,
+					EntityInput: data.EntityInput{
+						EntityID:                   userIDs["harry"],
+						AttributesToCreateOrUpdate: map[string]interface{}{"house": "gryffindor"},
+					},
+				},
+				{
+					Type:        data.DeleteEntityMutation,
+					EntityInput: data.EntityInput{EntityID: userIDs["ron"]},
+				},
+				newUserMutation("neville"),
+			},
+		},
+	}))
+	changeCommitID := <-mutator.onTransactionProcessed
+	assertCommittedTransaction(t, mutator, changeCommitID)
+
+	revertCommitID, err := mutator.Revert(changeCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, revertCommitID, <-mutator.onTransactionProcessed)
+	assertCommittedTransaction(t, mutator, revertCommitID)
+
+	expected, err := mutator.dataWithVersion.EntitiesAt(createCommitID)
+	assert.Nil(t, err)
+	actual, err := mutator.dataWithVersion.EntitiesAt(revertCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+
+	schema, _, err := mutator.dataWithVersion.SchemaHistories.FindLatestValueAt(revertCommitID, "users")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]data.Type{"name": data.StringDataType, "age": data.IntDataType}, schema.Attributes)
+
+	commit, _, err := mutator.dataWithVersion.FindCommit(revertCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("revert commit %v", changeCommitID), commit.Metadata.Message)
+
+	// nothing is left to undo
+	transactionID, err := mutator.Revert(changeCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), transactionID)
+
+	// a later commit changes harry's age again
+	assert.Nil(t, mutator.CreateTransaction(newUserUpdate(userIDs["harry"], 19)))
+	updateCommitID := <-mutator.onTransactionProcessed
+	assert.Nil(t, mutator.CreateTransaction(newUserUpdate(userIDs["harry"], 20)))
+	latestCommitID := <-mutator.onTransactionProcessed
+
+	_, err = mutator.Revert(updateCommitID)
+	assert.Equal(t, RevertConflicts{
+		CommitID: updateCommitID,
+		EntityConflicts: []data.EntityConflict{
+			{
+				EntityID:     userIDs["harry"],
+				SchemaName:   "users",
+				Attribute:    "age",
+				BaseValue:    float64(19),
+				MainValue:    float64(20),
+				BranchValue:  float64(17),
+				MainStatus:   history.UpdatedVersionStatus,
+				BranchStatus: history.UpdatedVersionStatus,
+			},
+		},
+		SchemaConflicts: []data.SchemaConflict{},
+	}, err)
+
+	latestCommit, err := mutator.dataWithVersion.GetLatestCommit()
+	assert.Nil(t, err)
+	assert.Equal(t, latestCommitID, latestCommit.CommittedTransactionID)
+
+	_, err = mutator.Revert(latestCommitID + 1)
+	assert.NotNil(t, err)
+}
+
+func TestMutator_Fork(t *testing.T) {
+	source := openMutator(t, storage.NewInMemoryMap())
+	source.Start()
+
+	for index := 0; index < 3; index++ {
+		assert.Nil(t, source.CreateTransaction(newWorkloadTransaction(index)))
+		<-source.onTransactionProcessed
+	}
+
+	assert.Nil(t, source.CreateTransaction(newWorkloadTransaction(4)))
+	forkCommitID := <-source.onTransactionProcessed
+	assert.Nil(t, source.CreateTransaction(newWorkloadTransaction(5)))
+	<-source.onTransactionProcessed
+
+	target := openMutator(t, storage.NewInMemoryMap())
+	target.Start()
+	assert.Nil(t, target.Fork(source.dataWithVersion, forkCommitID))
+
+	expected, err := source.dataWithVersion.EntitiesAt(forkCommitID)
+	assert.Nil(t, err)
+	actual, err := target.dataWithVersion.EntitiesAt(forkCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+
+	for _, index := range []int{0, 1, 2, 4} {
+		assertCommitted(t, target, index)
+	}
+
+	// the entities created afterwards don't reuse the copied IDs
+	assert.Nil(t, target.CreateTransaction(newWorkloadTransaction(8)))
+	commitID := <-target.onTransactionProcessed
+	assert.Less(t, forkCommitID, commitID)
+	entities := findEntities(t, target, commitID, "schema8")
+	assert.Equal(t, 1, len(entities))
+	for entityID := range expected {
+		assert.Less(t, entityID, entities[0].ID)
+	}
+}
+
+func TestMutator_RestoreEntity(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	mutator := openMutator(t, rawMap)
+	mutator.Start()
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.CreateSchemaMutation,
+					SchemaInput: data.SchemaInput{
+						Name: "users",
+						AttributesToCreateOrUpdate: map[string]data.Type{
+							"name": data.StringDataType,
+							"age":  data.IntDataType,
+						},
+					},
+				},
+				newUserMutation("harry"),
+				newUserMutation("ron"),
+			},
+		},
+	}))
+	createCommitID := <-mutator.onTransactionProcessed
+	userIDs := findUserIDs(t, mutator, createCommitID)
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.CreateSchemaAttributesMutation,
+					SchemaInput: data.SchemaInput{
+						Name:                       "users",
+						AttributesToCreateOrUpdate: map[string]data.Type{"house": data.StringDataType},
+					},
+				},
+				newUserUpdate(userIDs["harry"], 30).Mutations["users"][0],
+				{
+					Type: data.CreateEntityAttributesMutation,
+					EntityInput: data.EntityInput{
+						EntityID:                   userIDs["ron"],
+						AttributesToCreateOrUpdate: map[string]interface{}{"house": "gryffindor"},
+					},
+				},
+			},
+		},
+	}))
+	<-mutator.onTransactionProcessed
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type:        data.DeleteEntityMutation,
+					EntityInput: data.EntityInput{EntityID: userIDs["ron"]},
+				},
+			},
+		},
+	}))
+	deleteCommitID := <-mutator.onTransactionProcessed
+
+	// ron is undeleted without the house he got afterwards and harry gets his age back
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.RestoreEntityMutation,
+					EntityInput: data.EntityInput{
+						EntityID:        userIDs["harry"],
+						RestoreCommitID: createCommitID,
+					},
+				},
+				{
+					Type: data.RestoreEntityMutation,
+					EntityInput: data.EntityInput{
+						EntityID:        userIDs["ron"],
+						RestoreCommitID: createCommitID,
+					},
+				},
+			},
+		},
+	}))
+	restoreCommitID := <-mutator.onTransactionProcessed
+	assertCommittedTransaction(t, mutator, restoreCommitID)
+
+	expected, err := mutator.dataWithVersion.EntitiesAt(createCommitID)
+	assert.Nil(t, err)
+	actual, err := mutator.dataWithVersion.EntitiesAt(restoreCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+
+	// ron doesn't exist at the commit deleting him
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.RestoreEntityMutation,
+					EntityInput: data.EntityInput{
+						EntityID:        userIDs["ron"],
+						RestoreCommitID: deleteCommitID,
+					},
+				},
+			},
+		},
+	}))
+	transactionID := <-mutator.onTransactionProcessed
+	status, err := mutator.transactionStatus.Get(transactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, transactionAborted, status)
+
+	// the restored entity must fit the current schema
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.DeleteSchemaAttributesMutation,
+					SchemaInput: data.SchemaInput{
+						Name:               "users",
+						AttributesToDelete: []string{"age"},
+					},
+				},
+			},
+		},
+	}))
+	dropCommitID := <-mutator.onTransactionProcessed
+	assertCommittedTransaction(t, mutator, dropCommitID)
+
+	assert.Nil(t, mutator.CreateTransaction(TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.RestoreEntityMutation,
+					EntityInput: data.EntityInput{
+						EntityID:        userIDs["harry"],
+						RestoreCommitID: createCommitID,
+					},
+				},
+			},
+		},
+	}))
+	transactionID = <-mutator.onTransactionProcessed
+	status, err = mutator.transactionStatus.Get(transactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, transactionAborted, status)
+
+	harry, exist, err := mutator.dataWithVersion.EntityHistories.FindLatestValueAt(math.MaxUint64, userIDs["harry"])
+	assert.Nil(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, map[string]interface{}{"name": "harry"}, harry.Attributes)
+}
+
+func newUserMutation(name string) data.Mutation {
+	return data.Mutation{
+		Type: data.CreateEntityMutation,
+		EntityInput: data.EntityInput{
+			SchemaName:                 "users",
+			AttributesToCreateOrUpdate: map[string]interface{}{"name": name, "age": 17},
+		},
+	}
+}
+
+func newUserUpdate(entityID uint64, age int) TransactionInput {
+	return TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			"users": {
+				{
+					Type: data.UpdateEntityAttributesMutation,
+					EntityInput: data.EntityInput{
+						EntityID:                   entityID,
+						AttributesToCreateOrUpdate: map[string]interface{}{"age": age},
+					},
+				},
+			},
+		},
+	}
+}
+
+// findUserIDs returns the IDs of the users by their names
+func findUserIDs(t *testing.T, mutator *Mutator, commitID uint64) map[string]uint64 {
+	userIDs := make(map[string]uint64)
+	for _, entity := range findEntities(t, mutator, commitID, "users") {
+		userIDs[entity.Attributes["name"].(string)] = entity.ID
+	}
+
+	return userIDs
+}
+
+func assertCommittedTransaction(t *testing.T, mutator *Mutator, transactionID uint64) {
+	status, err := mutator.transactionStatus.Get(transactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, transactionCommitted, status)
+}
+
+// runWorkload commits the workload through a FaultyMap, reopens the mutator on the underlying map
+// and verifies committed transactions survive while aborted ones leave no trace.
+// It returns the number of operations on the FaultyMap.
+func runWorkload(t *testing.T, plan storage.FaultPlan) int {
+	rawMap := storage.NewInMemoryMap()
+	openMutator(t, rawMap)
+
+	faultyMap := storage.NewFaultyMap(rawMap, plan)
+	mutator := openMutator(t, faultyMap)
+	mutator.Start()
+
+	submitted := 0
+	for index := 0; index < workloadSize; index++ {
+		err := mutator.CreateTransaction(newWorkloadTransaction(index))
+		if err == nil {
+			submitted++
+		}
+	}
+
+	for ; submitted > 0; submitted-- {
+		select {
+		case <-mutator.onTransactionProcessed:
+		case <-time.After(10 * time.Second):
+			t.Fatal("transaction not processed")
+		}
+	}
+
+	operations := faultyMap.Operations()
+	mutator = openMutator(t, rawMap)
+	transactions := storedTransactions(t, mutator)
+
+	pending := 0
+	for _, transaction := range transactions {
+		index := workloadIndex(t, transaction)
+		status, done := findTransactionStatus(t, mutator, transaction.ID)
+		switch {
+		case !done:
+			// the transactions interrupted by the crash are rolled back & queued again
+			assertAborted(t, mutator, transaction.ID, index)
+			pending++
+		case status == transactionCommitted:
+			assertCommitted(t, mutator, index)
+		case status == transactionAborted:
+			assertAborted(t, mutator, transaction.ID, index)
+		}
+
+		assertSnapshots(t, mutator, transaction.ID)
+	}
+
+	assertSnapshots(t, mutator, math.MaxUint64)
+
+	// the store stays usable after the faults
+	mutator.Start()
+	for ; pending > 0; pending-- {
+		select {
+		case <-mutator.onTransactionProcessed:
+		case <-time.After(10 * time.Second):
+			t.Fatal("recovered transaction not processed")
+		}
+	}
+
+	for _, transaction := range transactions {
+		status, done := findTransactionStatus(t, mutator, transaction.ID)
+		assert.True(t, done, "transaction %v", transaction.ID)
+		if status == transactionCommitted {
+			assertCommitted(t, mutator, workloadIndex(t, transaction))
+		}
+	}
+
+	assert.Nil(t, mutator.CreateTransaction(newWorkloadTransaction(workloadSize)))
+	<-mutator.onTransactionProcessed
+	assertCommitted(t, mutator, workloadSize)
+	return operations
+}
+
+// runRecovery kills the mutator once the plan crashes the FaultyMap while it commits queued transactions,
+// then verifies the mutator reopened on the underlying map commits every transaction exactly once.
+// It returns the number of operations made to commit the transactions.
+func runRecovery(t *testing.T, plan storage.FaultPlan) int {
+	const transactionCount = 3
+
+	rawMap := storage.NewInMemoryMap()
+	openMutator(t, rawMap)
+
+	// the faults start with the commit of the queued transactions
+	queued := math.MaxInt
+	faultyMap := storage.NewFaultyMap(rawMap, func(index int, operation storage.Operation, key string) storage.Fault {
+		if index < queued {
+			return storage.Fault{}
+		}
+
+		return plan(index-queued, operation, key)
+	})
+	mutator := openMutator(t, faultyMap)
+	for index := 0; index < transactionCount; index++ {
+		assert.Nil(t, mutator.CreateTransaction(newWorkloadTransaction(index)))
+	}
+
+	queued = faultyMap.Operations()
+	mutator.Start()
+	for processed := 0; processed < transactionCount; processed++ {
+		select {
+		case <-mutator.onTransactionProcessed:
+		case <-time.After(10 * time.Second):
+			t.Fatal("transaction not processed")
+		}
+	}
+
+	operations := faultyMap.Operations() - queued
+	mutator = openMutator(t, rawMap)
+	transactions := storedTransactions(t, mutator)
+	assert.Equal(t, transactionCount, len(transactions))
+
+	pending := 0
+	for _, transaction := range transactions {
+		if _, done := findTransactionStatus(t, mutator, transaction.ID); !done {
+			assertAborted(t, mutator, transaction.ID, workloadIndex(t, transaction))
+			pending++
+		}
+	}
+
+	mutator.Start()
+	for ; pending > 0; pending-- {
+		select {
+		case <-mutator.onTransactionProcessed:
+		case <-time.After(10 * time.Second):
+			t.Fatal("recovered transaction not processed")
+		}
+	}
+
+	for _, transaction := range transactions {
+		assertCommittedTransaction(t, mutator, transaction.ID)
+		assertCommitted(t, mutator, workloadIndex(t, transaction))
+	}
+
+	assertSnapshots(t, mutator, math.MaxUint64)
+	return operations
+}
+
+// storedTransactions returns the transactions stored by the mutator in the order of their IDs
+func storedTransactions(t *testing.T, mutator *Mutator) []Transaction {
+	iterator := mutator.transactions.Ascend()
+	defer iterator.Close()
+
+	transactions := make([]Transaction, 0)
+	for iterator.Next() {
+		transactions = append(transactions, iterator.Value())
+	}
+
+	assert.Nil(t, iterator.Err())
+	return transactions
+}
+
+// findTransactionStatus returns the status of the transaction, false when it is neither committed nor aborted
+func findTransactionStatus(t *testing.T, mutator *Mutator, transactionID uint64) (TransactionStatus, bool) {
+	contain, err := mutator.transactionStatus.Contain(transactionID)
+	assert.Nil(t, err)
+	if !contain {
+		return "", false
+	}
+
+	status, err := mutator.transactionStatus.Get(transactionID)
+	assert.Nil(t, err)
+	return status, status != transactionStarted
+}
+
+// workloadIndex returns the index of the workload transaction
+func workloadIndex(t *testing.T, transaction Transaction) int {
+	var index int
+	for schemaName := range transaction.Mutations {
+		_, err := fmt.Sscanf(schemaName, "schema%d", &index)
+		assert.Nil(t, err)
+	}
+
+	return index
+}
+
+// newWorkloadTransaction creates a schema and an entity of it. Every 4th entity does not match its schema.
+func newWorkloadTransaction(index int) TransactionInput {
+	schemaName := fmt.Sprintf("schema%v", index)
+	var value interface{} = index
+	if index%4 == 3 {
+		value = "invalid"
+	}
+
+	return TransactionInput{
+		Mutations: map[string][]data.Mutation{
+			schemaName: {
+				{
+					Type: data.CreateSchemaMutation,
+					SchemaInput: data.SchemaInput{
+						Name:                       schemaName,
+						AttributesToCreateOrUpdate: map[string]data.Type{"value": data.IntDataType},
+					},
+				},
+				{
+					Type: data.CreateEntityMutation,
+					EntityInput: data.EntityInput{
+						SchemaName:                 schemaName,
+						AttributesToCreateOrUpdate: map[string]interface{}{"value": value},
+					},
+				},
+			},
+		},
+	}
+}
+
+func assertCommitted(t *testing.T, mutator *Mutator, index int) {
+	schemaName := fmt.Sprintf("schema%v", index)
+	assert.NotEqual(t, 3, index%4, "invalid transaction committed")
+
+	schema, exist, err := mutator.dataWithVersion.SchemaHistories.FindLatestValueAt(math.MaxUint64, schemaName)
+	assert.Nil(t, err)
+	assert.True(t, exist, schemaName)
+	assert.Equal(t, map[string]data.Type{"value": data.IntDataType}, schema.Attributes)
+
+	entities := findEntities(t, mutator, math.MaxUint64, schemaName)
+	assert.Equal(t, 1, len(entities), schemaName)
+	for _, entity := range entities {
+		assert.Equal(t, float64(index), entity.Attributes["value"])
+	}
+}
+
+func assertAborted(t *testing.T, mutator *Mutator, transactionID uint64, index int) {
+	schemaName := fmt.Sprintf("schema%v", index)
+	for _, commitID := range []uint64{transactionID, math.MaxUint64} {
+		_, exist, err := mutator.dataWithVersion.SchemaHistories.FindLatestValueAt(commitID, schemaName)
+		assert.Nil(t, err)
+		assert.False(t, exist, schemaName)
+		assert.Empty(t, findEntities(t, mutator, commitID, schemaName), schemaName)
+	}
+}
+
+// assertSnapshots verifies the entities read from the snapshots match the entities replayed from the histories
+func assertSnapshots(t *testing.T, mutator *Mutator, commitID uint64) {
+	expected, _, err := mutator.dataWithVersion.EntityHistories.ListAllLatestValuesAt(commitID)
+	assert.Nil(t, err)
+
+	actual, err := mutator.dataWithVersion.EntitiesAt(commitID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual, "commit %v", commitID)
+}
+
+func findEntities(t *testing.T, mutator *Mutator, commitID uint64, schemaName string) []data.Entity {
+	entities, _, err := mutator.dataWithVersion.EntityHistories.ListAllLatestValuesAt(commitID)
+	assert.Nil(t, err)
+
+	found := make([]data.Entity, 0)
+	for _, entity := range entities {
+		if entity.SchemaName == schemaName {
+			found = append(found, entity)
+		}
+	}
+
+	return found
+}
+
+// openBranchMutator forks the data of main at the commit into a branch
+func openBranchMutator(t *testing.T, rawMap storage.RawMap, mainMutator *Mutator, commitID uint64) *Mutator {
+	refGen, err := idgen.New(path.Join("idGens", "branchRefGen"), rawMap, 5)
+	assert.Nil(t, err)
+
+	dataWithVersion, err := data.NewWithVersion("branch", refGen, rawMap, reliable.JSONFormat, testSnapshotInterval)
+	assert.Nil(t, err)
+	assert.Nil(t, mainMutator.dataWithVersion.Fork(commitID, dataWithVersion))
+
+	mutator, err := NewBranchMutator(mainMutator, "branch", refGen, rawMap, reliable.JSONFormat, dataWithVersion)
+	assert.Nil(t, err)
+	return mutator
+}
+
+// failAppendCommit fails the batch recording a commit, which starts with the snapshot or the commit index
+func failAppendCommit(rawMap storage.RawMap) *storage.FaultyMap {
+	return storage.NewFaultyMap(rawMap, func(_ int, operation storage.Operation, key string) storage.Fault {
+		if operation == storage.CommitOperation &&
+			(strings.HasPrefix(key, "database/snapshots/entities/") || strings.HasPrefix(key, "database/commitIndex/")) {
+			return storage.Fault{Err: storage.InjectedFault("fail to append commit")}
+		}
+
+		return storage.Fault{}
+	})
+}
+
+func openMutator(t *testing.T, rawMap storage.RawMap) *Mutator {
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 5)
+	assert.Nil(t, err)
+
+	dataWithVersion, err := data.NewWithVersion("database", refGen, rawMap, reliable.JSONFormat, testSnapshotInterval)
+	assert.Nil(t, err)
+
+	mutator, err := NewMutator("database", refGen, rawMap, reliable.JSONFormat, dataWithVersion)
+	assert.Nil(t, err)
+	return mutator
+}
@@ -7,6 +7,9 @@ import (
 type Transaction struct {
 	ID        uint64                     `json:"id"`
 	Mutations map[string][]data.Mutation `json:"mutations"` // key: schema name, value: mutation
+	Metadata  data.CommitMetadata        `json:"metadata"`
+	// recovered transactions are read back from storage, where the attribute values lose their types
+	recovered bool
 }
 
 type TransactionStatus string
@@ -27,12 +27,27 @@ type DatabaseClient interface {
 	ListAllDatabases(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Databases, error)
 	CreateDatabase(ctx context.Context, in *CreateDatabaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteDatabase(ctx context.Context, in *DeleteDatabaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ForkDatabase(ctx context.Context, in *ForkDatabaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetLatestCommit(ctx context.Context, in *GetLatestCommitRequest, opts ...grpc.CallOption) (*Commit, error)
+	GetCommitAt(ctx context.Context, in *GetCommitAtRequest, opts ...grpc.CallOption) (*Commit, error)
+	ListCommits(ctx context.Context, in *ListCommitsRequest, opts ...grpc.CallOption) (Database_ListCommitsClient, error)
 	QueryEntitiesAtCommit(ctx context.Context, in *QueryAtCommitRequest, opts ...grpc.CallOption) (*Entities, error)
 	QueryEntityGroupsAtCommit(ctx context.Context, in *QueryAtCommitRequest, opts ...grpc.CallOption) (*Groups, error)
+	QueryEntitiesAsOf(ctx context.Context, in *QueryAsOfRequest, opts ...grpc.CallOption) (*Entities, error)
+	QueryEntityGroupsAsOf(ctx context.Context, in *QueryAsOfRequest, opts ...grpc.CallOption) (*Groups, error)
 	QueryEntitiesBetweenCommits(ctx context.Context, in *QueryBetweenCommitsRequest, opts ...grpc.CallOption) (*Entities, error)
 	QueryEntityGroupsBetweenCommits(ctx context.Context, in *QueryBetweenCommitsRequest, opts ...grpc.CallOption) (*Entities, error)
+	BlameEntity(ctx context.Context, in *BlameEntityRequest, opts ...grpc.CallOption) (*EntityBlame, error)
+	GetRetentionPolicy(ctx context.Context, in *GetRetentionPolicyRequest, opts ...grpc.CallOption) (*RetentionPolicy, error)
+	SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DiffCommits(ctx context.Context, in *DiffCommitsRequest, opts ...grpc.CallOption) (*CommitDiff, error)
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ResolveTag(ctx context.Context, in *ResolveTagRequest, opts ...grpc.CallOption) (*Tag, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*Tags, error)
+	CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpc.CallOption) (*Branches, error)
+	MergeBranch(ctx context.Context, in *MergeBranchRequest, opts ...grpc.CallOption) (*MergeResult, error)
 }
 
 type databaseClient struct {
@@ -70,6 +85,15 @@ func (c *databaseClient) DeleteDatabase(ctx context.Context, in *DeleteDatabaseR
 	return out, nil
 }
 
+func (c *databaseClient) ForkDatabase(ctx context.Context, in *ForkDatabaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.Database/ForkDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/proto.Database/CreateTransaction", in, out, opts...)
@@ -88,6 +112,47 @@ func (c *databaseClient) GetLatestCommit(ctx context.Context, in *GetLatestCommi
 	return out, nil
 }
 
+func (c *databaseClient) GetCommitAt(ctx context.Context, in *GetCommitAtRequest, opts ...grpc.CallOption) (*Commit, error) {
+	out := new(Commit)
+	err := c.cc.Invoke(ctx, "/proto.Database/GetCommitAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListCommits(ctx context.Context, in *ListCommitsRequest, opts ...grpc.CallOption) (Database_ListCommitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[0], "/proto.Database/ListCommits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseListCommitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Database_ListCommitsClient interface {
+	Recv() (*Commit, error)
+	grpc.ClientStream
+}
+
+type databaseListCommitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseListCommitsClient) Recv() (*Commit, error) {
+	m := new(Commit)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *databaseClient) QueryEntitiesAtCommit(ctx context.Context, in *QueryAtCommitRequest, opts ...grpc.CallOption) (*Entities, error) {
 	out := new(Entities)
 	err := c.cc.Invoke(ctx, "/proto.Database/QueryEntitiesAtCommit", in, out, opts...)
@@ -106,6 +171,24 @@ func (c *databaseClient) QueryEntityGroupsAtCommit(ctx context.Context, in *Quer
 	return out, nil
 }
 
+func (c *databaseClient) QueryEntitiesAsOf(ctx context.Context, in *QueryAsOfRequest, opts ...grpc.CallOption) (*Entities, error) {
+	out := new(Entities)
+	err := c.cc.Invoke(ctx, "/proto.Database/QueryEntitiesAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) QueryEntityGroupsAsOf(ctx context.Context, in *QueryAsOfRequest, opts ...grpc.CallOption) (*Groups, error) {
+	out := new(Groups)
+	err := c.cc.Invoke(ctx, "/proto.Database/QueryEntityGroupsAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) QueryEntitiesBetweenCommits(ctx context.Context, in *QueryBetweenCommitsRequest, opts ...grpc.CallOption) (*Entities, error) {
 	out := new(Entities)
 	err := c.cc.Invoke(ctx, "/proto.Database/QueryEntitiesBetweenCommits", in, out, opts...)
@@ -124,6 +207,96 @@ func (c *databaseClient) QueryEntityGroupsBetweenCommits(ctx context.Context, in
 	return out, nil
 }
 
+func (c *databaseClient) BlameEntity(ctx context.Context, in *BlameEntityRequest, opts ...grpc.CallOption) (*EntityBlame, error) {
+	out := new(EntityBlame)
+	err := c.cc.Invoke(ctx, "/proto.Database/BlameEntity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) GetRetentionPolicy(ctx context.Context, in *GetRetentionPolicyRequest, opts ...grpc.CallOption) (*RetentionPolicy, error) {
+	out := new(RetentionPolicy)
+	err := c.cc.Invoke(ctx, "/proto.Database/GetRetentionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.Database/SetRetentionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) DiffCommits(ctx context.Context, in *DiffCommitsRequest, opts ...grpc.CallOption) (*CommitDiff, error) {
+	out := new(CommitDiff)
+	err := c.cc.Invoke(ctx, "/proto.Database/DiffCommits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.Database/CreateTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ResolveTag(ctx context.Context, in *ResolveTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, "/proto.Database/ResolveTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*Tags, error) {
+	out := new(Tags)
+	err := c.cc.Invoke(ctx, "/proto.Database/ListTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.Database/CreateBranch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpc.CallOption) (*Branches, error) {
+	out := new(Branches)
+	err := c.cc.Invoke(ctx, "/proto.Database/ListBranches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) MergeBranch(ctx context.Context, in *MergeBranchRequest, opts ...grpc.CallOption) (*MergeResult, error) {
+	out := new(MergeResult)
+	err := c.cc.Invoke(ctx, "/proto.Database/MergeBranch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 // All implementations must embed UnimplementedDatabaseServer
 // for forward compatibility
@@ -131,12 +304,27 @@ type DatabaseServer interface {
 	ListAllDatabases(context.Context, *emptypb.Empty) (*Databases, error)
 	CreateDatabase(context.Context, *CreateDatabaseRequest) (*emptypb.Empty, error)
 	DeleteDatabase(context.Context, *DeleteDatabaseRequest) (*emptypb.Empty, error)
+	ForkDatabase(context.Context, *ForkDatabaseRequest) (*emptypb.Empty, error)
 	CreateTransaction(context.Context, *CreateTransactionRequest) (*emptypb.Empty, error)
 	GetLatestCommit(context.Context, *GetLatestCommitRequest) (*Commit, error)
+	GetCommitAt(context.Context, *GetCommitAtRequest) (*Commit, error)
+	ListCommits(*ListCommitsRequest, Database_ListCommitsServer) error
 	QueryEntitiesAtCommit(context.Context, *QueryAtCommitRequest) (*Entities, error)
 	QueryEntityGroupsAtCommit(context.Context, *QueryAtCommitRequest) (*Groups, error)
+	QueryEntitiesAsOf(context.Context, *QueryAsOfRequest) (*Entities, error)
+	QueryEntityGroupsAsOf(context.Context, *QueryAsOfRequest) (*Groups, error)
 	QueryEntitiesBetweenCommits(context.Context, *QueryBetweenCommitsRequest) (*Entities, error)
 	QueryEntityGroupsBetweenCommits(context.Context, *QueryBetweenCommitsRequest) (*Entities, error)
+	BlameEntity(context.Context, *BlameEntityRequest) (*EntityBlame, error)
+	GetRetentionPolicy(context.Context, *GetRetentionPolicyRequest) (*RetentionPolicy, error)
+	SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*emptypb.Empty, error)
+	DiffCommits(context.Context, *DiffCommitsRequest) (*CommitDiff, error)
+	CreateTag(context.Context, *CreateTagRequest) (*emptypb.Empty, error)
+	ResolveTag(context.Context, *ResolveTagRequest) (*Tag, error)
+	ListTags(context.Context, *ListTagsRequest) (*Tags, error)
+	CreateBranch(context.Context, *CreateBranchRequest) (*emptypb.Empty, error)
+	ListBranches(context.Context, *ListBranchesRequest) (*Branches, error)
+	MergeBranch(context.Context, *MergeBranchRequest) (*MergeResult, error)
 	mustEmbedUnimplementedDatabaseServer()
 }
 
@@ -153,24 +341,69 @@ func (UnimplementedDatabaseServer) CreateDatabase(context.Context, *CreateDataba
 func (UnimplementedDatabaseServer) DeleteDatabase(context.Context, *DeleteDatabaseRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDatabase not implemented")
 }
+func (UnimplementedDatabaseServer) ForkDatabase(context.Context, *ForkDatabaseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForkDatabase not implemented")
+}
 func (UnimplementedDatabaseServer) CreateTransaction(context.Context, *CreateTransactionRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
 }
 func (UnimplementedDatabaseServer) GetLatestCommit(context.Context, *GetLatestCommitRequest) (*Commit, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLatestCommit not implemented")
 }
+func (UnimplementedDatabaseServer) GetCommitAt(context.Context, *GetCommitAtRequest) (*Commit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommitAt not implemented")
+}
+func (UnimplementedDatabaseServer) ListCommits(*ListCommitsRequest, Database_ListCommitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListCommits not implemented")
+}
 func (UnimplementedDatabaseServer) QueryEntitiesAtCommit(context.Context, *QueryAtCommitRequest) (*Entities, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryEntitiesAtCommit not implemented")
 }
 func (UnimplementedDatabaseServer) QueryEntityGroupsAtCommit(context.Context, *QueryAtCommitRequest) (*Groups, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryEntityGroupsAtCommit not implemented")
 }
+func (UnimplementedDatabaseServer) QueryEntitiesAsOf(context.Context, *QueryAsOfRequest) (*Entities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryEntitiesAsOf not implemented")
+}
+func (UnimplementedDatabaseServer) QueryEntityGroupsAsOf(context.Context, *QueryAsOfRequest) (*Groups, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryEntityGroupsAsOf not implemented")
+}
 func (UnimplementedDatabaseServer) QueryEntitiesBetweenCommits(context.Context, *QueryBetweenCommitsRequest) (*Entities, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryEntitiesBetweenCommits not implemented")
 }
 func (UnimplementedDatabaseServer) QueryEntityGroupsBetweenCommits(context.Context, *QueryBetweenCommitsRequest) (*Entities, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryEntityGroupsBetweenCommits not implemented")
 }
+func (UnimplementedDatabaseServer) BlameEntity(context.Context, *BlameEntityRequest) (*EntityBlame, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlameEntity not implemented")
+}
+func (UnimplementedDatabaseServer) GetRetentionPolicy(context.Context, *GetRetentionPolicyRequest) (*RetentionPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRetentionPolicy not implemented")
+}
+func (UnimplementedDatabaseServer) SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRetentionPolicy not implemented")
+}
+func (UnimplementedDatabaseServer) DiffCommits(context.Context, *DiffCommitsRequest) (*CommitDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffCommits not implemented")
+}
+func (UnimplementedDatabaseServer) CreateTag(context.Context, *CreateTagRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedDatabaseServer) ResolveTag(context.Context, *ResolveTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveTag not implemented")
+}
+func (UnimplementedDatabaseServer) ListTags(context.Context, *ListTagsRequest) (*Tags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedDatabaseServer) CreateBranch(context.Context, *CreateBranchRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBranch not implemented")
+}
+func (UnimplementedDatabaseServer) ListBranches(context.Context, *ListBranchesRequest) (*Branches, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBranches not implemented")
+}
+func (UnimplementedDatabaseServer) MergeBranch(context.Context, *MergeBranchRequest) (*MergeResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeBranch not implemented")
+}
 func (UnimplementedDatabaseServer) mustEmbedUnimplementedDatabaseServer() {}
 
 // UnsafeDatabaseServer may be embedded to opt out of forward compatibility for this service.
@@ -238,6 +471,24 @@ func _Database_DeleteDatabase_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_ForkDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForkDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ForkDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/ForkDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ForkDatabase(ctx, req.(*ForkDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_CreateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateTransactionRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +525,45 @@ func _Database_GetLatestCommit_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_GetCommitAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommitAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetCommitAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/GetCommitAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetCommitAt(ctx, req.(*GetCommitAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListCommits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCommitsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).ListCommits(m, &databaseListCommitsServer{stream})
+}
+
+type Database_ListCommitsServer interface {
+	Send(*Commit) error
+	grpc.ServerStream
+}
+
+type databaseListCommitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseListCommitsServer) Send(m *Commit) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Database_QueryEntitiesAtCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryAtCommitRequest)
 	if err := dec(in); err != nil {
@@ -310,6 +600,42 @@ func _Database_QueryEntityGroupsAtCommit_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_QueryEntitiesAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).QueryEntitiesAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/QueryEntitiesAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).QueryEntitiesAsOf(ctx, req.(*QueryAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_QueryEntityGroupsAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).QueryEntityGroupsAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/QueryEntityGroupsAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).QueryEntityGroupsAsOf(ctx, req.(*QueryAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_QueryEntitiesBetweenCommits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryBetweenCommitsRequest)
 	if err := dec(in); err != nil {
@@ -346,6 +672,186 @@ func _Database_QueryEntityGroupsBetweenCommits_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_BlameEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlameEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).BlameEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/BlameEntity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).BlameEntity(ctx, req.(*BlameEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_GetRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/GetRetentionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetRetentionPolicy(ctx, req.(*GetRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SetRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SetRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/SetRetentionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SetRetentionPolicy(ctx, req.(*SetRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_DiffCommits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffCommitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).DiffCommits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/DiffCommits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).DiffCommits(ctx, req.(*DiffCommitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/CreateTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ResolveTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ResolveTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/ResolveTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ResolveTag(ctx, req.(*ResolveTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/ListTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_CreateBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).CreateBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/CreateBranch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).CreateBranch(ctx, req.(*CreateBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListBranches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBranchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ListBranches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/ListBranches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ListBranches(ctx, req.(*ListBranchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_MergeBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).MergeBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Database/MergeBranch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).MergeBranch(ctx, req.(*MergeBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Database_ServiceDesc is the grpc.ServiceDesc for Database service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -365,6 +871,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDatabase",
 			Handler:    _Database_DeleteDatabase_Handler,
 		},
+		{
+			MethodName: "ForkDatabase",
+			Handler:    _Database_ForkDatabase_Handler,
+		},
 		{
 			MethodName: "CreateTransaction",
 			Handler:    _Database_CreateTransaction_Handler,
@@ -373,6 +883,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLatestCommit",
 			Handler:    _Database_GetLatestCommit_Handler,
 		},
+		{
+			MethodName: "GetCommitAt",
+			Handler:    _Database_GetCommitAt_Handler,
+		},
 		{
 			MethodName: "QueryEntitiesAtCommit",
 			Handler:    _Database_QueryEntitiesAtCommit_Handler,
@@ -381,6 +895,14 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryEntityGroupsAtCommit",
 			Handler:    _Database_QueryEntityGroupsAtCommit_Handler,
 		},
+		{
+			MethodName: "QueryEntitiesAsOf",
+			Handler:    _Database_QueryEntitiesAsOf_Handler,
+		},
+		{
+			MethodName: "QueryEntityGroupsAsOf",
+			Handler:    _Database_QueryEntityGroupsAsOf_Handler,
+		},
 		{
 			MethodName: "QueryEntitiesBetweenCommits",
 			Handler:    _Database_QueryEntitiesBetweenCommits_Handler,
@@ -389,7 +911,53 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryEntityGroupsBetweenCommits",
 			Handler:    _Database_QueryEntityGroupsBetweenCommits_Handler,
 		},
+		{
+			MethodName: "BlameEntity",
+			Handler:    _Database_BlameEntity_Handler,
+		},
+		{
+			MethodName: "GetRetentionPolicy",
+			Handler:    _Database_GetRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "SetRetentionPolicy",
+			Handler:    _Database_SetRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "DiffCommits",
+			Handler:    _Database_DiffCommits_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _Database_CreateTag_Handler,
+		},
+		{
+			MethodName: "ResolveTag",
+			Handler:    _Database_ResolveTag_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _Database_ListTags_Handler,
+		},
+		{
+			MethodName: "CreateBranch",
+			Handler:    _Database_CreateBranch_Handler,
+		},
+		{
+			MethodName: "ListBranches",
+			Handler:    _Database_ListBranches_Handler,
+		},
+		{
+			MethodName: "MergeBranch",
+			Handler:    _Database_MergeBranch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListCommits",
+			Handler:       _Database_ListCommits_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/database.proto",
 }
@@ -2,8 +2,11 @@ package proto
 
 import (
 	"fmt"
+	"time"
 
 	"tstore/data"
+	"tstore/database"
+	"tstore/history"
 	"tstore/mutation"
 	"tstore/query"
 	"tstore/query/lang"
@@ -28,6 +31,7 @@ var fromProtoMutationType = map[MutationType]data.MutationType{
 	MutationType_CreateEntityAttributes: data.CreateEntityAttributesMutation,
 	MutationType_DeleteEntityAttributes: data.DeleteEntityAttributesMutation,
 	MutationType_UpdateEntityAttributes: data.UpdateEntityAttributesMutation,
+	MutationType_RestoreEntity:          data.RestoreEntityMutation,
 }
 
 var fromProtoOperator = map[Operator]lang.Operator{
@@ -50,6 +54,12 @@ var fromProtoOperator = map[Operator]lang.Operator{
 	Operator_EachGroup:            lang.EachGroupOperator,
 }
 
+var fromProtoVersionStatus = map[VersionStatus]history.VersionStatus{
+	VersionStatus_Created: history.CreatedVersionStatus,
+	VersionStatus_Updated: history.UpdatedVersionStatus,
+	VersionStatus_Deleted: history.DeletedVersionStatus,
+}
+
 func FromProtoTransactionInput(protoTransactionInput *Transaction) (mutation.TransactionInput, error) {
 	mutationsMap := make(map[string][]data.Mutation)
 	for schema, protoMutations := range protoTransactionInput.Mutations {
@@ -61,7 +71,22 @@ func FromProtoTransactionInput(protoTransactionInput *Transaction) (mutation.Tra
 		mutationsMap[schema] = mutations
 	}
 
-	return mutation.TransactionInput{Mutations: mutationsMap}, nil
+	return mutation.TransactionInput{
+		Mutations: mutationsMap,
+		Metadata:  FromProtoCommitMetadata(protoTransactionInput.Metadata),
+	}, nil
+}
+
+func FromProtoCommitMetadata(protoMetadata *CommitMetadata) data.CommitMetadata {
+	if protoMetadata == nil {
+		return data.CommitMetadata{}
+	}
+
+	return data.CommitMetadata{
+		Author:  protoMetadata.Author,
+		Message: protoMetadata.Message,
+		Labels:  protoMetadata.Labels,
+	}
 }
 
 func FromProtoExpression(protoExpression *Expression) *lang.Expression {
@@ -85,9 +110,248 @@ func FromProtoExpression(protoExpression *Expression) *lang.Expression {
 }
 
 func FromProtoCommit(protoCommit *Commit) data.Commit {
-	return data.Commit{
+	commit := data.Commit{
 		CommittedTransactionID: protoCommit.CommittedTransactionId,
 		CommittedAt:            protoCommit.CommittedAt.AsTime(),
+		Metadata:               FromProtoCommitMetadata(protoCommit.Metadata),
+	}
+	if protoCommit.Summary == nil {
+		return commit
+	}
+
+	commit.Summary.MutationCounts = make(map[data.MutationType]int)
+	for _, mutationCount := range protoCommit.Summary.MutationCounts {
+		commit.Summary.MutationCounts[fromProtoMutationType[mutationCount.Type]] = int(mutationCount.Count)
+	}
+
+	commit.Summary.Schemas = protoCommit.Summary.Schemas
+	return commit
+}
+
+func FromProtoTags(protoTags *Tags) []data.Tag {
+	tags := make([]data.Tag, 0)
+	for _, protoTag := range protoTags.Tags {
+		tags = append(tags, FromProtoTag(protoTag))
+	}
+
+	return tags
+}
+
+func FromProtoTag(protoTag *Tag) data.Tag {
+	return data.Tag{
+		Name:     protoTag.Name,
+		CommitID: protoTag.TransactionId,
+	}
+}
+
+func FromProtoBranches(protoBranches *Branches) []database.Branch {
+	branches := make([]database.Branch, 0)
+	for _, protoBranch := range protoBranches.Branches {
+		branches = append(branches, database.Branch{
+			Name:           protoBranch.Name,
+			BaseCommitID:   protoBranch.BaseTransactionId,
+			MergedCommitID: protoBranch.MergedTransactionId,
+		})
+	}
+
+	return branches
+}
+
+func FromProtoMergeResult(protoResult *MergeResult) (data.MergeResult, error) {
+	entityConflicts := make([]data.EntityConflict, 0)
+	for _, protoConflict := range protoResult.EntityConflicts {
+		conflict := data.EntityConflict{
+			EntityID:     protoConflict.EntityId,
+			SchemaName:   protoConflict.SchemaName,
+			Attribute:    protoConflict.Attribute,
+			MainStatus:   fromProtoVersionStatus[protoConflict.MainStatus],
+			BranchStatus: fromProtoVersionStatus[protoConflict.BranchStatus],
+		}
+		baseValue, err := fromProtoOptionalValue(protoConflict.BaseValue)
+		if err != nil {
+			return data.MergeResult{}, err
+		}
+
+		mainValue, err := fromProtoOptionalValue(protoConflict.MainValue)
+		if err != nil {
+			return data.MergeResult{}, err
+		}
+
+		branchValue, err := fromProtoOptionalValue(protoConflict.BranchValue)
+		if err != nil {
+			return data.MergeResult{}, err
+		}
+
+		conflict.BaseValue = baseValue
+		conflict.MainValue = mainValue
+		conflict.BranchValue = branchValue
+		entityConflicts = append(entityConflicts, conflict)
+	}
+
+	schemaConflicts := make([]data.SchemaConflict, 0)
+	for _, protoConflict := range protoResult.SchemaConflicts {
+		conflict := data.SchemaConflict{
+			SchemaName:   protoConflict.SchemaName,
+			Attribute:    protoConflict.Attribute,
+			BaseType:     data.NoneDataType,
+			MainType:     data.NoneDataType,
+			BranchType:   data.NoneDataType,
+			MainStatus:   fromProtoVersionStatus[protoConflict.MainStatus],
+			BranchStatus: fromProtoVersionStatus[protoConflict.BranchStatus],
+		}
+		// the types are absent on the sides where the attribute doesn't exist
+		if conflict.Attribute != "" {
+			if conflict.MainStatus != history.CreatedVersionStatus {
+				conflict.BaseType = lang.ToDatabaseDataType[fromProtoDataType[protoConflict.BaseType]]
+			}
+
+			if conflict.MainStatus != history.DeletedVersionStatus {
+				conflict.MainType = lang.ToDatabaseDataType[fromProtoDataType[protoConflict.MainType]]
+			}
+
+			if conflict.BranchStatus != history.DeletedVersionStatus {
+				conflict.BranchType = lang.ToDatabaseDataType[fromProtoDataType[protoConflict.BranchType]]
+			}
+		}
+
+		schemaConflicts = append(schemaConflicts, conflict)
+	}
+
+	return data.MergeResult{
+		CommitID:        protoResult.TransactionId,
+		EntityConflicts: entityConflicts,
+		SchemaConflicts: schemaConflicts,
+	}, nil
+}
+
+func FromProtoCommitLogQuery(request *ListCommitsRequest) data.CommitLogQuery {
+	query := data.CommitLogQuery{
+		BeginCommitID: request.BeginTransactionId,
+		EndCommitID:   request.EndTransactionId,
+		BeginTag:      request.BeginTag,
+		EndTag:        request.EndTag,
+		NewestFirst:   request.NewestFirst,
+		PageToken:     request.PageToken,
+		PageSize:      int(request.PageSize),
+	}
+	if request.BeginTime != nil {
+		query.BeginTime = request.BeginTime.AsTime()
+	}
+
+	if request.EndTime != nil {
+		query.EndTime = request.EndTime.AsTime()
+	}
+
+	return query
+}
+
+func FromProtoEntityBlame(protoBlame *EntityBlame) (data.EntityBlame, error) {
+	attributes := make([]data.AttributeBlame, 0)
+	for _, protoAttribute := range protoBlame.Attributes {
+		value, err := fromProtoValue(protoAttribute.Value)
+		if err != nil {
+			return data.EntityBlame{}, err
+		}
+
+		attributes = append(attributes, data.AttributeBlame{
+			Attribute:   protoAttribute.Attribute,
+			Value:       value,
+			CommitID:    protoAttribute.CommittedTransactionId,
+			CommittedAt: protoAttribute.CommittedAt.AsTime(),
+			Status:      fromProtoVersionStatus[protoAttribute.Status],
+		})
+	}
+
+	return data.EntityBlame{
+		EntityID:   protoBlame.EntityId,
+		SchemaName: protoBlame.SchemaName,
+		Attributes: attributes,
+	}, nil
+}
+
+func FromProtoCommitDiff(protoDiff *CommitDiff) (data.CommitDiff, error) {
+	entities := make([]data.EntityDiff, 0)
+	for _, protoEntity := range protoDiff.Entities {
+		attributes := make([]data.AttributeDiff, 0)
+		for _, protoAttribute := range protoEntity.Attributes {
+			attribute := data.AttributeDiff{
+				Attribute: protoAttribute.Attribute,
+				Status:    fromProtoVersionStatus[protoAttribute.Status],
+			}
+			if protoAttribute.OldValue != nil {
+				value, err := fromProtoValue(protoAttribute.OldValue)
+				if err != nil {
+					return data.CommitDiff{}, err
+				}
+
+				attribute.OldValue = value
+			}
+
+			if protoAttribute.NewValue != nil {
+				value, err := fromProtoValue(protoAttribute.NewValue)
+				if err != nil {
+					return data.CommitDiff{}, err
+				}
+
+				attribute.NewValue = value
+			}
+
+			attributes = append(attributes, attribute)
+		}
+
+		entities = append(entities, data.EntityDiff{
+			EntityID:   protoEntity.EntityId,
+			SchemaName: protoEntity.SchemaName,
+			Status:     fromProtoVersionStatus[protoEntity.Status],
+			Attributes: attributes,
+		})
+	}
+
+	schemas := make([]data.SchemaDiff, 0)
+	for _, protoSchema := range protoDiff.Schemas {
+		attributes := make([]data.SchemaAttributeDiff, 0)
+		for _, protoAttribute := range protoSchema.Attributes {
+			status := fromProtoVersionStatus[protoAttribute.Status]
+			// the types are absent on the side where the attribute doesn't exist
+			oldType := lang.ToDatabaseDataType[fromProtoDataType[protoAttribute.OldType]]
+			if status == history.CreatedVersionStatus {
+				oldType = data.NoneDataType
+			}
+
+			newType := lang.ToDatabaseDataType[fromProtoDataType[protoAttribute.NewType]]
+			if status == history.DeletedVersionStatus {
+				newType = data.NoneDataType
+			}
+
+			attributes = append(attributes, data.SchemaAttributeDiff{
+				Attribute: protoAttribute.Attribute,
+				OldType:   oldType,
+				NewType:   newType,
+				Status:    status,
+			})
+		}
+
+		schemas = append(schemas, data.SchemaDiff{
+			SchemaName: protoSchema.SchemaName,
+			Status:     fromProtoVersionStatus[protoSchema.Status],
+			Attributes: attributes,
+		})
+	}
+
+	return data.CommitDiff{
+		Entities: entities,
+		Schemas:  schemas,
+	}, nil
+}
+
+func FromProtoRetentionPolicy(protoPolicy *RetentionPolicy) data.RetentionPolicy {
+	if protoPolicy == nil {
+		return data.RetentionPolicy{}
+	}
+
+	return data.RetentionPolicy{
+		KeepCommits:  int(protoPolicy.KeepCommits),
+		KeepDuration: time.Duration(protoPolicy.KeepDurationSeconds) * time.Second,
 	}
 }
 
@@ -219,9 +483,19 @@ func fromProtoEntityInput(protoEntityInput *EntityInput) (data.EntityInput, erro
 		SchemaName:                 protoEntityInput.SchemaName,
 		AttributesToCreateOrUpdate: createOrUpdateAttributes,
 		AttributesToDelete:         protoEntityInput.AttributesToDelete,
+		RestoreCommitID:            protoEntityInput.RestoreTransactionId,
 	}, nil
 }
 
 func fromProtoValue(protoValue *Value) (interface{}, error) {
 	return lang.ParseValue(fromProtoDataType[protoValue.Type], protoValue.Content)
 }
+
+// fromProtoOptionalValue returns nil when the value is absent
+func fromProtoOptionalValue(protoValue *Value) (interface{}, error) {
+	if protoValue == nil {
+		return nil, nil
+	}
+
+	return fromProtoValue(protoValue)
+}
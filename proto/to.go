@@ -1,8 +1,13 @@
 package proto
 
 import (
+	"time"
+
 	"tstore/data"
+	"tstore/database"
+	"tstore/history"
 	"tstore/mutation"
+	"tstore/query"
 	"tstore/query/lang"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -27,6 +32,7 @@ var toProtoMutationType = map[data.MutationType]MutationType{
 	data.CreateEntityAttributesMutation: MutationType_CreateEntityAttributes,
 	data.DeleteEntityAttributesMutation: MutationType_DeleteEntityAttributes,
 	data.UpdateEntityAttributesMutation: MutationType_UpdateEntityAttributes,
+	data.RestoreEntityMutation:          MutationType_RestoreEntity,
 }
 
 var toProtoOperator = map[lang.Operator]Operator{
@@ -48,6 +54,12 @@ var toProtoOperator = map[lang.Operator]Operator{
 	lang.EachGroupOperator:            Operator_EachGroup,
 }
 
+var toProtoVersionStatus = map[history.VersionStatus]VersionStatus{
+	history.CreatedVersionStatus: VersionStatus_Created,
+	history.UpdatedVersionStatus: VersionStatus_Updated,
+	history.DeletedVersionStatus: VersionStatus_Deleted,
+}
+
 func ToProtoDatabases(dbNames []string) *Databases {
 	return &Databases{Databases: dbNames}
 }
@@ -58,7 +70,18 @@ func ToProtoTransaction(transactionInput mutation.TransactionInput) *Transaction
 		protoMutations[schema] = toProtoMutations(mutations)
 	}
 
-	return &Transaction{Mutations: protoMutations}
+	return &Transaction{
+		Mutations: protoMutations,
+		Metadata:  ToProtoCommitMetadata(transactionInput.Metadata),
+	}
+}
+
+func ToProtoCommitMetadata(metadata data.CommitMetadata) *CommitMetadata {
+	return &CommitMetadata{
+		Author:  metadata.Author,
+		Message: metadata.Message,
+		Labels:  metadata.Labels,
+	}
 }
 
 func ToProtoExpression(expression lang.Expression) *Expression {
@@ -122,6 +145,7 @@ func toProtoEntityInput(entityInput data.EntityInput) *EntityInput {
 		SchemaName:                 entityInput.SchemaName,
 		AttributesToCreateOrUpdate: createOrUpdateAttributes,
 		AttributesToDelete:         entityInput.AttributesToDelete,
+		RestoreTransactionId:       entityInput.RestoreCommitID,
 	}
 }
 
@@ -147,6 +171,15 @@ func ToProtoEntities(entities []data.Entity) *Entities {
 	return &Entities{Entities: protoEntities}
 }
 
+func ToProtoGroups(groups query.Groups[data.Entity]) *Groups {
+	protoGroups := make(map[string]*Entities)
+	for key, entities := range groups {
+		protoGroups[key] = ToProtoEntities(entities)
+	}
+
+	return &Groups{Groups: protoGroups}
+}
+
 func toProtoValue(value interface{}) *Value {
 	return &Value{
 		Type:    toProtoDataType[lang.GetDataType(value)],
@@ -155,8 +188,174 @@ func toProtoValue(value interface{}) *Value {
 }
 
 func ToProtoCommit(commit data.Commit) *Commit {
+	mutationCounts := make([]*MutationCount, 0)
+	for mutationType, count := range commit.Summary.MutationCounts {
+		mutationCounts = append(mutationCounts, &MutationCount{
+			Type:  toProtoMutationType[mutationType],
+			Count: uint64(count),
+		})
+	}
+
 	return &Commit{
 		CommittedTransactionId: commit.CommittedTransactionID,
 		CommittedAt:            timestamppb.New(commit.CommittedAt),
+		Summary: &CommitSummary{
+			MutationCounts: mutationCounts,
+			Schemas:        commit.Summary.Schemas,
+		},
+		Metadata: ToProtoCommitMetadata(commit.Metadata),
+	}
+}
+
+func ToProtoTags(tags []data.Tag) *Tags {
+	protoTags := make([]*Tag, 0)
+	for _, tag := range tags {
+		protoTags = append(protoTags, ToProtoTag(tag))
+	}
+
+	return &Tags{Tags: protoTags}
+}
+
+func ToProtoTag(tag data.Tag) *Tag {
+	return &Tag{
+		Name:          tag.Name,
+		TransactionId: tag.CommitID,
+	}
+}
+
+func ToProtoEntityBlame(blame data.EntityBlame) *EntityBlame {
+	protoAttributes := make([]*AttributeBlame, 0)
+	for _, attribute := range blame.Attributes {
+		protoAttributes = append(protoAttributes, &AttributeBlame{
+			Attribute:              attribute.Attribute,
+			Value:                  toProtoValue(attribute.Value),
+			CommittedTransactionId: attribute.CommitID,
+			CommittedAt:            timestamppb.New(attribute.CommittedAt),
+			Status:                 toProtoVersionStatus[attribute.Status],
+		})
+	}
+
+	return &EntityBlame{
+		EntityId:   blame.EntityID,
+		SchemaName: blame.SchemaName,
+		Attributes: protoAttributes,
+	}
+}
+
+func ToProtoCommitDiff(diff data.CommitDiff) *CommitDiff {
+	protoEntities := make([]*EntityDiff, 0)
+	for _, entityDiff := range diff.Entities {
+		protoAttributes := make([]*AttributeDiff, 0)
+		for _, attribute := range entityDiff.Attributes {
+			protoAttribute := &AttributeDiff{
+				Attribute: attribute.Attribute,
+				Status:    toProtoVersionStatus[attribute.Status],
+			}
+			if attribute.OldValue != nil {
+				protoAttribute.OldValue = toProtoValue(attribute.OldValue)
+			}
+
+			if attribute.NewValue != nil {
+				protoAttribute.NewValue = toProtoValue(attribute.NewValue)
+			}
+
+			protoAttributes = append(protoAttributes, protoAttribute)
+		}
+
+		protoEntities = append(protoEntities, &EntityDiff{
+			EntityId:   entityDiff.EntityID,
+			SchemaName: entityDiff.SchemaName,
+			Status:     toProtoVersionStatus[entityDiff.Status],
+			Attributes: protoAttributes,
+		})
+	}
+
+	protoSchemas := make([]*SchemaDiff, 0)
+	for _, schemaDiff := range diff.Schemas {
+		protoAttributes := make([]*SchemaAttributeDiff, 0)
+		for _, attribute := range schemaDiff.Attributes {
+			protoAttributes = append(protoAttributes, &SchemaAttributeDiff{
+				Attribute: attribute.Attribute,
+				OldType:   toProtoDataType[lang.FromDatabaseDataType[attribute.OldType]],
+				NewType:   toProtoDataType[lang.FromDatabaseDataType[attribute.NewType]],
+				Status:    toProtoVersionStatus[attribute.Status],
+			})
+		}
+
+		protoSchemas = append(protoSchemas, &SchemaDiff{
+			SchemaName: schemaDiff.SchemaName,
+			Status:     toProtoVersionStatus[schemaDiff.Status],
+			Attributes: protoAttributes,
+		})
+	}
+
+	return &CommitDiff{
+		Entities: protoEntities,
+		Schemas:  protoSchemas,
+	}
+}
+
+func ToProtoBranches(branches []database.Branch) *Branches {
+	protoBranches := make([]*Branch, 0)
+	for _, branch := range branches {
+		protoBranches = append(protoBranches, &Branch{
+			Name:                branch.Name,
+			BaseTransactionId:   branch.BaseCommitID,
+			MergedTransactionId: branch.MergedCommitID,
+		})
+	}
+
+	return &Branches{Branches: protoBranches}
+}
+
+func ToProtoMergeResult(result data.MergeResult) *MergeResult {
+	protoEntityConflicts := make([]*EntityConflict, 0)
+	for _, conflict := range result.EntityConflicts {
+		protoConflict := &EntityConflict{
+			EntityId:     conflict.EntityID,
+			SchemaName:   conflict.SchemaName,
+			Attribute:    conflict.Attribute,
+			MainStatus:   toProtoVersionStatus[conflict.MainStatus],
+			BranchStatus: toProtoVersionStatus[conflict.BranchStatus],
+		}
+		if conflict.BaseValue != nil {
+			protoConflict.BaseValue = toProtoValue(conflict.BaseValue)
+		}
+
+		if conflict.MainValue != nil {
+			protoConflict.MainValue = toProtoValue(conflict.MainValue)
+		}
+
+		if conflict.BranchValue != nil {
+			protoConflict.BranchValue = toProtoValue(conflict.BranchValue)
+		}
+
+		protoEntityConflicts = append(protoEntityConflicts, protoConflict)
+	}
+
+	protoSchemaConflicts := make([]*SchemaConflict, 0)
+	for _, conflict := range result.SchemaConflicts {
+		protoSchemaConflicts = append(protoSchemaConflicts, &SchemaConflict{
+			SchemaName:   conflict.SchemaName,
+			Attribute:    conflict.Attribute,
+			BaseType:     toProtoDataType[lang.FromDatabaseDataType[conflict.BaseType]],
+			MainType:     toProtoDataType[lang.FromDatabaseDataType[conflict.MainType]],
+			BranchType:   toProtoDataType[lang.FromDatabaseDataType[conflict.BranchType]],
+			MainStatus:   toProtoVersionStatus[conflict.MainStatus],
+			BranchStatus: toProtoVersionStatus[conflict.BranchStatus],
+		})
+	}
+
+	return &MergeResult{
+		TransactionId:   result.CommitID,
+		EntityConflicts: protoEntityConflicts,
+		SchemaConflicts: protoSchemaConflicts,
+	}
+}
+
+func ToProtoRetentionPolicy(policy data.RetentionPolicy) *RetentionPolicy {
+	return &RetentionPolicy{
+		KeepCommits:         uint64(policy.KeepCommits),
+		KeepDurationSeconds: int64(policy.KeepDuration / time.Second),
 	}
 }
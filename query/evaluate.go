@@ -27,6 +27,19 @@ func evaluateCollector[Item any](createAttributeSelector SelectorCreator[Item],
 	return collector.(Collector[Item]), nil
 }
 
+func evaluateFilter[Item any](createAttributeSelector SelectorCreator[Item], expression lang.Expression) (Filter[Item], error) {
+	filter, dataType, err := evaluateExpression(createAttributeSelector, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataType != lang.FilterExpressionDataType {
+		return nil, errors.New("must be filter")
+	}
+
+	return filter.(Filter[Item]), nil
+}
+
 func evaluateGroupCollector[Item any](createAttributeSelector SelectorCreator[Item], expression lang.Expression) (GroupCollector[Item], error) {
 	collector, dataType, err := evaluateExpression(createAttributeSelector, expression)
 	if err != nil {
@@ -69,7 +82,7 @@ func evaluateExpression[Item any](
 
 		return evaluateNot(createAttributeSelector, expression.Inputs[0])
 	case lang.AllOperator:
-		return All[Item], lang.FilterExpressionDataType, nil
+		return Filter[Item](All[Item]), lang.FilterExpressionDataType, nil
 	case lang.EqualToOperator:
 		if len(expression.Inputs) != 2 {
 			return nil, "", errors.New("and must have 2 parameters")
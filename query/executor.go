@@ -1,6 +1,8 @@
 package query
 
 import (
+	"fmt"
+
 	"tstore/data"
 	"tstore/history"
 	"tstore/query/lang"
@@ -47,6 +49,11 @@ func (e Executor) QueryEntitiesBetweenCommits(
 		return nil, err
 	}
 
+	err = e.dataWithVersion.CheckRetained(beginCommitID)
+	if err != nil {
+		return nil, err
+	}
+
 	versionGroups, err := e.dataWithVersion.EntityHistories.FindAllChangesBetween(beginCommitID, endCommitID)
 	if err != nil {
 		return nil, err
@@ -59,8 +66,30 @@ func (e Executor) QueryEntitiesBetweenCommits(
 	return versionGroups, nil
 }
 
+func (e Executor) BlameEntity(commitID uint64, entityID uint64) (data.EntityBlame, error) {
+	blame, exist, err := e.dataWithVersion.BlameEntity(commitID, entityID)
+	if err != nil {
+		return data.EntityBlame{}, err
+	}
+
+	if !exist {
+		return data.EntityBlame{}, fmt.Errorf("entity not found: id=%v, commitID=%v", entityID, commitID)
+	}
+
+	return blame, nil
+}
+
+func (e Executor) DiffCommits(fromCommitID uint64, toCommitID uint64, filter lang.Expression) (data.CommitDiff, error) {
+	entityFilter, err := evaluateFilter(CreateEntityAttributeSelector, filter)
+	if err != nil {
+		return data.CommitDiff{}, err
+	}
+
+	return e.dataWithVersion.DiffCommits(fromCommitID, toCommitID, entityFilter)
+}
+
 func (e Executor) getEntitiesAtCommit(commitID uint64) ([]data.Entity, error) {
-	entityMap, _, err := e.dataWithVersion.EntityHistories.ListAllLatestValuesAt(commitID)
+	entityMap, err := e.dataWithVersion.EntitiesAt(commitID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,760 @@
+package reliable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tags of the values encoded by BinaryCodec
+const (
+	nilTag byte = iota
+	boolTag
+	intTag
+	int8Tag
+	int16Tag
+	int32Tag
+	int64Tag
+	uintTag
+	uint8Tag
+	uint16Tag
+	uint32Tag
+	uint64Tag
+	float32Tag
+	float64Tag
+	stringTag
+	bytesTag
+	timeTag
+	listTag
+	mapTag
+	structTag
+)
+
+var intTags = map[reflect.Kind]byte{
+	reflect.Int:   intTag,
+	reflect.Int8:  int8Tag,
+	reflect.Int16: int16Tag,
+	reflect.Int32: int32Tag,
+	reflect.Int64: int64Tag,
+}
+
+var uintTags = map[reflect.Kind]byte{
+	reflect.Uint:    uintTag,
+	reflect.Uint8:   uint8Tag,
+	reflect.Uint16:  uint16Tag,
+	reflect.Uint32:  uint32Tag,
+	reflect.Uint64:  uint64Tag,
+	reflect.Uintptr: uint64Tag,
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+var errBinaryValueTruncated = errors.New("binary value truncated")
+
+// BinaryCodec encodes values in a compact self-describing binary format.
+//
+// Every value is prefixed with a tag recording its kind, so numbers keep their exact width when they
+// are decoded into interface{} (an int32 rune stays an int32 instead of becoming a float64 like JSON)
+// and time.Time keeps its nanoseconds & zone offset. Struct fields are encoded with their names,
+// so fields can be added or removed without breaking the values written before.
+type BinaryCodec struct {
+}
+
+func (b BinaryCodec) Format() Format {
+	return BinaryFormat
+}
+
+func (b BinaryCodec) Marshal(value interface{}) ([]byte, error) {
+	return appendValue(make([]byte, 0, 64), reflect.ValueOf(value))
+}
+
+func (b BinaryCodec) Unmarshal(buf []byte, value interface{}) error {
+	target := reflect.ValueOf(value)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer: %T", value)
+	}
+
+	decoder := binaryDecoder{buf: buf}
+	err := decoder.decode(target.Elem())
+	if err != nil {
+		return err
+	}
+
+	if decoder.offset != len(buf) {
+		return fmt.Errorf("unexpected bytes after binary value: count=%v", len(buf)-decoder.offset)
+	}
+
+	return nil
+}
+
+func appendValue(buf []byte, value reflect.Value) ([]byte, error) {
+	if !value.IsValid() {
+		return append(buf, nilTag), nil
+	}
+
+	if value.Type() == timeType {
+		timeBuf, err := value.Interface().(time.Time).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		return appendBytes(append(buf, timeTag), timeBuf), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if value.IsNil() {
+			return append(buf, nilTag), nil
+		}
+
+		return appendValue(buf, value.Elem())
+	case reflect.Bool:
+		if value.Bool() {
+			return append(buf, boolTag, 1), nil
+		}
+
+		return append(buf, boolTag, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendVarint(append(buf, intTags[value.Kind()]), value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUvarint(append(buf, uintTags[value.Kind()]), value.Uint()), nil
+	case reflect.Float32:
+		var floatBuf [4]byte
+		binary.LittleEndian.PutUint32(floatBuf[:], math.Float32bits(float32(value.Float())))
+		return append(append(buf, float32Tag), floatBuf[:]...), nil
+	case reflect.Float64:
+		var floatBuf [8]byte
+		binary.LittleEndian.PutUint64(floatBuf[:], math.Float64bits(value.Float()))
+		return append(append(buf, float64Tag), floatBuf[:]...), nil
+	case reflect.String:
+		return appendBytes(append(buf, stringTag), []byte(value.String())), nil
+	case reflect.Slice:
+		if value.IsNil() {
+			return append(buf, nilTag), nil
+		}
+
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return appendBytes(append(buf, bytesTag), value.Bytes()), nil
+		}
+
+		return appendList(buf, value)
+	case reflect.Array:
+		return appendList(buf, value)
+	case reflect.Map:
+		if value.IsNil() {
+			return append(buf, nilTag), nil
+		}
+
+		return appendMap(buf, value)
+	case reflect.Struct:
+		return appendStruct(buf, value)
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", value.Type())
+	}
+}
+
+func appendList(buf []byte, value reflect.Value) ([]byte, error) {
+	buf = appendUvarint(append(buf, listTag), uint64(value.Len()))
+	for index := 0; index < value.Len(); index++ {
+		var err error
+		buf, err = appendValue(buf, value.Index(index))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func appendMap(buf []byte, value reflect.Value) ([]byte, error) {
+	type entry struct {
+		key   []byte
+		value reflect.Value
+	}
+
+	// entries are sorted by their encoded keys, so equal maps are always encoded to the same bytes
+	entries := make([]entry, 0, value.Len())
+	iterator := value.MapRange()
+	for iterator.Next() {
+		key, err := appendValue(nil, iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry{key: key, value: iterator.Value()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	buf = appendUvarint(append(buf, mapTag), uint64(len(entries)))
+	for _, entry := range entries {
+		var err error
+		buf, err = appendValue(append(buf, entry.key...), entry.value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func appendStruct(buf []byte, value reflect.Value) ([]byte, error) {
+	fields := getStructFields(value.Type())
+	buf = appendUvarint(append(buf, structTag), uint64(len(fields)))
+	for _, field := range fields {
+		var err error
+		buf, err = appendValue(appendBytes(buf, []byte(field.name)), value.Field(field.index))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func appendBytes(buf []byte, data []byte) []byte {
+	return append(appendUvarint(buf, uint64(len(data))), data...)
+}
+
+func appendUvarint(buf []byte, value uint64) []byte {
+	var varintBuf [binary.MaxVarintLen64]byte
+	size := binary.PutUvarint(varintBuf[:], value)
+	return append(buf, varintBuf[:size]...)
+}
+
+func appendVarint(buf []byte, value int64) []byte {
+	var varintBuf [binary.MaxVarintLen64]byte
+	size := binary.PutVarint(varintBuf[:], value)
+	return append(buf, varintBuf[:size]...)
+}
+
+type structField struct {
+	name  string
+	index int
+}
+
+// structFields caches the encoded fields of each struct type
+var structFields sync.Map
+
+// getStructFields returns the exported fields of a struct, named after their JSON names when they have one
+func getStructFields(structType reflect.Type) []structField {
+	cached, ok := structFields.Load(structType)
+	if ok {
+		return cached.([]structField)
+	}
+
+	fields := make([]structField, 0, structType.NumField())
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == "-" {
+			continue
+		}
+
+		if jsonName != "" {
+			name = jsonName
+		}
+
+		fields = append(fields, structField{name: name, index: index})
+	}
+
+	structFields.Store(structType, fields)
+	return fields
+}
+
+type binaryDecoder struct {
+	buf    []byte
+	offset int
+}
+
+func (b *binaryDecoder) decode(target reflect.Value) error {
+	tag, err := b.readByte()
+	if err != nil {
+		return err
+	}
+
+	return b.decodeTagged(tag, target)
+}
+
+func (b *binaryDecoder) decodeTagged(tag byte, target reflect.Value) error {
+	if tag == nilTag {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Interface:
+		value, err := b.decodeInterface(tag)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.TypeOf(value).AssignableTo(target.Type()) {
+			return fmt.Errorf("cannot assign %T to %v", value, target.Type())
+		}
+
+		target.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Ptr:
+		elem := reflect.New(target.Type().Elem())
+		err := b.decodeTagged(tag, elem.Elem())
+		if err != nil {
+			return err
+		}
+
+		target.Set(elem)
+		return nil
+	}
+
+	if target.Type() == timeType {
+		if tag != timeTag {
+			return fmt.Errorf("cannot decode tag %v into time.Time", tag)
+		}
+
+		timeBuf, err := b.readBytes()
+		if err != nil {
+			return err
+		}
+
+		var value time.Time
+		err = value.UnmarshalBinary(timeBuf)
+		if err != nil {
+			return err
+		}
+
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch tag {
+	case boolTag:
+		value, err := b.readByte()
+		if err != nil {
+			return err
+		}
+
+		if target.Kind() != reflect.Bool {
+			return fmt.Errorf("cannot decode bool into %v", target.Type())
+		}
+
+		target.SetBool(value != 0)
+		return nil
+	case intTag, int8Tag, int16Tag, int32Tag, int64Tag:
+		value, err := b.readVarint()
+		if err != nil {
+			return err
+		}
+
+		return setInt(target, value)
+	case uintTag, uint8Tag, uint16Tag, uint32Tag, uint64Tag:
+		value, err := b.readUvarint()
+		if err != nil {
+			return err
+		}
+
+		return setUint(target, value)
+	case float32Tag, float64Tag:
+		value, err := b.readFloat(tag)
+		if err != nil {
+			return err
+		}
+
+		if target.Kind() != reflect.Float32 && target.Kind() != reflect.Float64 {
+			return fmt.Errorf("cannot decode float into %v", target.Type())
+		}
+
+		target.SetFloat(value)
+		return nil
+	case stringTag, bytesTag:
+		value, err := b.readBytes()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case target.Kind() == reflect.String:
+			target.SetString(string(value))
+		case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8:
+			target.SetBytes(append([]byte{}, value...))
+		default:
+			return fmt.Errorf("cannot decode string into %v", target.Type())
+		}
+
+		return nil
+	case listTag:
+		return b.decodeList(target)
+	case mapTag:
+		return b.decodeMap(target)
+	case structTag:
+		return b.decodeStruct(target)
+	default:
+		return fmt.Errorf("unknown binary tag: %v", tag)
+	}
+}
+
+func (b *binaryDecoder) decodeList(target reflect.Value) error {
+	length, err := b.readLength()
+	if err != nil {
+		return err
+	}
+
+	switch target.Kind() {
+	case reflect.Slice:
+		target.Set(reflect.MakeSlice(target.Type(), length, length))
+	case reflect.Array:
+		if target.Len() != length {
+			return fmt.Errorf("array length mismatch: expected=%v actual=%v", target.Len(), length)
+		}
+	default:
+		return fmt.Errorf("cannot decode list into %v", target.Type())
+	}
+
+	for index := 0; index < length; index++ {
+		err = b.decode(target.Index(index))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *binaryDecoder) decodeMap(target reflect.Value) error {
+	length, err := b.readLength()
+	if err != nil {
+		return err
+	}
+
+	if target.Kind() != reflect.Map {
+		return fmt.Errorf("cannot decode map into %v", target.Type())
+	}
+
+	target.Set(reflect.MakeMapWithSize(target.Type(), length))
+	for index := 0; index < length; index++ {
+		key := reflect.New(target.Type().Key()).Elem()
+		err = b.decode(key)
+		if err != nil {
+			return err
+		}
+
+		value := reflect.New(target.Type().Elem()).Elem()
+		err = b.decode(value)
+		if err != nil {
+			return err
+		}
+
+		target.SetMapIndex(key, value)
+	}
+
+	return nil
+}
+
+func (b *binaryDecoder) decodeStruct(target reflect.Value) error {
+	length, err := b.readLength()
+	if err != nil {
+		return err
+	}
+
+	if target.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode struct into %v", target.Type())
+	}
+
+	fields := make(map[string]int)
+	for _, field := range getStructFields(target.Type()) {
+		fields[field.name] = field.index
+	}
+
+	target.Set(reflect.Zero(target.Type()))
+	for index := 0; index < length; index++ {
+		name, err := b.readBytes()
+		if err != nil {
+			return err
+		}
+
+		fieldIndex, ok := fields[string(name)]
+		if !ok {
+			// the field was removed from the struct after the value was written
+			var ignored interface{}
+			err = b.decode(reflect.ValueOf(&ignored).Elem())
+		} else {
+			err = b.decode(target.Field(fieldIndex))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeInterface decodes a value into the Go type recorded by its tag.
+// Lists become []interface{}, structs & maps with string keys become map[string]interface{}.
+func (b *binaryDecoder) decodeInterface(tag byte) (interface{}, error) {
+	switch tag {
+	case boolTag:
+		value, err := b.readByte()
+		return value != 0, err
+	case intTag, int8Tag, int16Tag, int32Tag, int64Tag:
+		value, err := b.readVarint()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tag {
+		case intTag:
+			return int(value), nil
+		case int8Tag:
+			return int8(value), nil
+		case int16Tag:
+			return int16(value), nil
+		case int32Tag:
+			return int32(value), nil
+		default:
+			return value, nil
+		}
+	case uintTag, uint8Tag, uint16Tag, uint32Tag, uint64Tag:
+		value, err := b.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tag {
+		case uintTag:
+			return uint(value), nil
+		case uint8Tag:
+			return uint8(value), nil
+		case uint16Tag:
+			return uint16(value), nil
+		case uint32Tag:
+			return uint32(value), nil
+		default:
+			return value, nil
+		}
+	case float32Tag:
+		value, err := b.readFloat(tag)
+		return float32(value), err
+	case float64Tag:
+		return b.readFloat(tag)
+	case stringTag:
+		value, err := b.readBytes()
+		return string(value), err
+	case bytesTag:
+		value, err := b.readBytes()
+		return append([]byte{}, value...), err
+	case timeTag:
+		var value time.Time
+		err := b.decodeTagged(tag, reflect.ValueOf(&value).Elem())
+		return value, err
+	case listTag:
+		var value []interface{}
+		err := b.decodeList(reflect.ValueOf(&value).Elem())
+		return value, err
+	case mapTag:
+		return b.decodeInterfaceMap()
+	case structTag:
+		length, err := b.readLength()
+		if err != nil {
+			return nil, err
+		}
+
+		value := make(map[string]interface{}, length)
+		for index := 0; index < length; index++ {
+			name, err := b.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			var fieldValue interface{}
+			err = b.decode(reflect.ValueOf(&fieldValue).Elem())
+			if err != nil {
+				return nil, err
+			}
+
+			value[string(name)] = fieldValue
+		}
+
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown binary tag: %v", tag)
+	}
+}
+
+func (b *binaryDecoder) decodeInterfaceMap() (interface{}, error) {
+	length, err := b.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]interface{}, length)
+	values := make([]interface{}, length)
+	stringKeys := true
+	for index := 0; index < length; index++ {
+		err = b.decode(reflect.ValueOf(&keys[index]).Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		if keys[index] == nil || !reflect.TypeOf(keys[index]).Comparable() {
+			return nil, fmt.Errorf("invalid map key: %v", keys[index])
+		}
+
+		_, ok := keys[index].(string)
+		stringKeys = stringKeys && ok
+
+		err = b.decode(reflect.ValueOf(&values[index]).Elem())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if stringKeys {
+		value := make(map[string]interface{}, length)
+		for index, key := range keys {
+			value[key.(string)] = values[index]
+		}
+
+		return value, nil
+	}
+
+	value := make(map[interface{}]interface{}, length)
+	for index, key := range keys {
+		value[key] = values[index]
+	}
+
+	return value, nil
+}
+
+func setInt(target reflect.Value, value int64) error {
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if target.OverflowInt(value) {
+			return fmt.Errorf("%v overflows %v", value, target.Type())
+		}
+
+		target.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value < 0 || target.OverflowUint(uint64(value)) {
+			return fmt.Errorf("%v overflows %v", value, target.Type())
+		}
+
+		target.SetUint(uint64(value))
+	case reflect.Float32, reflect.Float64:
+		target.SetFloat(float64(value))
+	default:
+		return fmt.Errorf("cannot decode int into %v", target.Type())
+	}
+
+	return nil
+}
+
+func setUint(target reflect.Value, value uint64) error {
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value > math.MaxInt64 || target.OverflowInt(int64(value)) {
+			return fmt.Errorf("%v overflows %v", value, target.Type())
+		}
+
+		target.SetInt(int64(value))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if target.OverflowUint(value) {
+			return fmt.Errorf("%v overflows %v", value, target.Type())
+		}
+
+		target.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		target.SetFloat(float64(value))
+	default:
+		return fmt.Errorf("cannot decode uint into %v", target.Type())
+	}
+
+	return nil
+}
+
+func (b *binaryDecoder) readByte() (byte, error) {
+	if b.offset >= len(b.buf) {
+		return 0, errBinaryValueTruncated
+	}
+
+	value := b.buf[b.offset]
+	b.offset++
+	return value, nil
+}
+
+func (b *binaryDecoder) readUvarint() (uint64, error) {
+	value, size := binary.Uvarint(b.buf[b.offset:])
+	if size <= 0 {
+		return 0, errBinaryValueTruncated
+	}
+
+	b.offset += size
+	return value, nil
+}
+
+func (b *binaryDecoder) readVarint() (int64, error) {
+	value, size := binary.Varint(b.buf[b.offset:])
+	if size <= 0 {
+		return 0, errBinaryValueTruncated
+	}
+
+	b.offset += size
+	return value, nil
+}
+
+// readLength reads the length of a list, map or struct, which can't exceed the remaining bytes
+func (b *binaryDecoder) readLength() (int, error) {
+	length, err := b.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	if length > uint64(len(b.buf)-b.offset) {
+		return 0, errBinaryValueTruncated
+	}
+
+	return int(length), nil
+}
+
+func (b *binaryDecoder) readFloat(tag byte) (float64, error) {
+	size := 8
+	if tag == float32Tag {
+		size = 4
+	}
+
+	if len(b.buf)-b.offset < size {
+		return 0, errBinaryValueTruncated
+	}
+
+	buf := b.buf[b.offset : b.offset+size]
+	b.offset += size
+	if tag == float32Tag {
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(buf))), nil
+	}
+
+	return math.Float64frombits(binary.LittleEndian.Uint64(buf)), nil
+}
+
+func (b *binaryDecoder) readBytes() ([]byte, error) {
+	length, err := b.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	value := b.buf[b.offset : b.offset+length]
+	b.offset += length
+	return value, nil
+}
@@ -0,0 +1,873 @@
+package reliable
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+
+	"tstore/idgen"
+	"tstore/storage"
+	"tstore/types"
+)
+
+// defaultBTreeOrder is the maximum number of keys in a node
+const defaultBTreeOrder = 64
+
+type bTreeNode[Key types.Comparable, Value any] struct {
+	Leaf bool  `json:"leaf"`
+	Keys []Key `json:"keys"`
+	// Values are only stored in leaves
+	Values []Value `json:"values,omitempty"`
+	// Children are only stored in internal nodes, keys in Children[i] are within [Keys[i-1], Keys[i])
+	Children []string `json:"children,omitempty"`
+	// Prev & Next link the leaves in key order
+	Prev string `json:"prev,omitempty"`
+	Next string `json:"next,omitempty"`
+}
+
+type BTreeEntry[Key types.Comparable, Value any] struct {
+	Key   Key   `json:"key"`
+	Value Value `json:"value"`
+}
+
+type bTreeSplit[Key types.Comparable] struct {
+	// key is the smallest key of the new right node
+	key      Key
+	rightRef string
+}
+
+// BTree is an ordered map persisted as a B+ tree.
+//
+// Each node is stored under its own key and every operation writes all the nodes it changes in a single
+// batch, so a crash in the middle of a split or a merge never leaves a partially updated tree behind.
+type BTree[Key types.Comparable, Value any] struct {
+	storagePath string
+	refGen      *idgen.IDGen
+	rawMap      storage.RawMap
+	codec       Codec
+	order       int
+}
+
+func (b BTree[Key, Value]) Get(key Key) (Value, bool, error) {
+	node, err := b.findLeaf(key)
+	if err != nil {
+		log.Println(err)
+		return *new(Value), false, err
+	}
+
+	index := lowerBound(node.Keys, key)
+	if index == len(node.Keys) || node.Keys[index] != key {
+		return *new(Value), false, nil
+	}
+
+	return node.Values[index], true, nil
+}
+
+// Put inserts the key or replaces its value when it already exists.
+func (b BTree[Key, Value]) Put(key Key, value Value) error {
+	return storage.RunInBatch(b.rawMap, func(batch storage.RawMap) error {
+		tree := b.withRawMap(batch)
+		rootRef, err := tree.getRootRef()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		split, inserted, err := tree.insert(rootRef, key, value)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if split != nil {
+			newRootRef, err := tree.createNode(bTreeNode[Key, Value]{
+				Keys:     []Key{split.key},
+				Children: []string{rootRef, split.rightRef},
+			})
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			err = tree.setRootRef(newRootRef)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+
+		if !inserted {
+			return nil
+		}
+
+		return tree.addLength(1)
+	})
+}
+
+// Delete removes the key and returns whether it existed.
+func (b BTree[Key, Value]) Delete(key Key) (bool, error) {
+	var deleted bool
+	err := storage.RunInBatch(b.rawMap, func(batch storage.RawMap) error {
+		tree := b.withRawMap(batch)
+		rootRef, err := tree.getRootRef()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		deleted, err = tree.delete(rootRef, key)
+		if err != nil || !deleted {
+			return err
+		}
+
+		root, err := tree.getNode(rootRef)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if !root.Leaf && len(root.Keys) == 0 {
+			// the only child of the root becomes the root
+			err = tree.setRootRef(root.Children[0])
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			err = batch.Delete(rootRef)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+
+		return tree.addLength(-1)
+	})
+	return deleted, err
+}
+
+func (b BTree[Key, Value]) Len() (int, error) {
+	buf, err := b.rawMap.Get(b.lengthPath())
+	if err != nil {
+		log.Println(err)
+		return 0, err
+	}
+
+	var length int
+	err = b.codec.Unmarshal(buf, &length)
+	return length, err
+}
+
+// BulkLoad builds the tree bottom up from entries sorted by strictly ascending keys.
+// It is much faster than inserting the entries one by one and only works on an empty tree.
+func (b BTree[Key, Value]) BulkLoad(entries []BTreeEntry[Key, Value]) error {
+	for index := 1; index < len(entries); index++ {
+		if entries[index-1].Key >= entries[index].Key {
+			return fmt.Errorf("bulk load entries not sorted: index=%v key=%v", index, entries[index].Key)
+		}
+	}
+
+	return storage.RunInBatch(b.rawMap, func(batch storage.RawMap) error {
+		tree := b.withRawMap(batch)
+		length, err := tree.Len()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if length > 0 {
+			return fmt.Errorf("bulk load requires an empty tree: length=%v", length)
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		rootRef, err := tree.getRootRef()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = batch.Delete(rootRef)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		leaves := splitEvenly(len(entries), tree.order)
+		refs := make([]string, len(leaves))
+		lowKeys := make([]Key, len(leaves))
+		for index := range leaves {
+			refs[index], err = tree.createNodeRef()
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+
+		start := 0
+		for index, size := range leaves {
+			leaf := bTreeNode[Key, Value]{Leaf: true}
+			for _, entry := range entries[start : start+size] {
+				leaf.Keys = append(leaf.Keys, entry.Key)
+				leaf.Values = append(leaf.Values, entry.Value)
+			}
+
+			if index > 0 {
+				leaf.Prev = refs[index-1]
+			}
+
+			if index < len(leaves)-1 {
+				leaf.Next = refs[index+1]
+			}
+
+			err = tree.setNode(refs[index], leaf)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			lowKeys[index] = leaf.Keys[0]
+			start += size
+		}
+
+		for len(refs) > 1 {
+			// each internal node holds up to order+1 children
+			groups := splitEvenly(len(refs), tree.order+1)
+			parentRefs := make([]string, 0, len(groups))
+			parentLowKeys := make([]Key, 0, len(groups))
+			start = 0
+			for _, size := range groups {
+				parent := bTreeNode[Key, Value]{
+					Keys:     append([]Key{}, lowKeys[start+1:start+size]...),
+					Children: append([]string{}, refs[start:start+size]...),
+				}
+				parentRef, err := tree.createNode(parent)
+				if err != nil {
+					log.Println(err)
+					return err
+				}
+
+				parentRefs = append(parentRefs, parentRef)
+				parentLowKeys = append(parentLowKeys, lowKeys[start])
+				start += size
+			}
+
+			refs = parentRefs
+			lowKeys = parentLowKeys
+		}
+
+		err = tree.setRootRef(refs[0])
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return tree.addLength(len(entries))
+	})
+}
+
+// Ascend iterates through all the entries in ascending key order.
+func (b BTree[Key, Value]) Ascend() *BTreeIterator[Key, Value] {
+	return &BTreeIterator[Key, Value]{
+		tree: &b,
+		seek: func() (bTreeNode[Key, Value], int, error) {
+			node, err := b.findEdgeLeaf(false)
+			return node, 0, err
+		},
+	}
+}
+
+// AscendFrom iterates in ascending key order through the entries whose keys are larger than or equal to start.
+func (b BTree[Key, Value]) AscendFrom(start Key) *BTreeIterator[Key, Value] {
+	return &BTreeIterator[Key, Value]{
+		tree: &b,
+		seek: func() (bTreeNode[Key, Value], int, error) {
+			node, err := b.findLeaf(start)
+			return node, lowerBound(node.Keys, start), err
+		},
+	}
+}
+
+// Descend iterates through all the entries in descending key order.
+func (b BTree[Key, Value]) Descend() *BTreeIterator[Key, Value] {
+	return &BTreeIterator[Key, Value]{
+		tree:    &b,
+		reverse: true,
+		seek: func() (bTreeNode[Key, Value], int, error) {
+			node, err := b.findEdgeLeaf(true)
+			return node, len(node.Keys) - 1, err
+		},
+	}
+}
+
+// DescendFrom iterates in descending key order through the entries whose keys are smaller than or equal to start.
+func (b BTree[Key, Value]) DescendFrom(start Key) *BTreeIterator[Key, Value] {
+	return &BTreeIterator[Key, Value]{
+		tree:    &b,
+		reverse: true,
+		seek: func() (bTreeNode[Key, Value], int, error) {
+			node, err := b.findLeaf(start)
+			return node, upperBound(node.Keys, start) - 1, err
+		},
+	}
+}
+
+// insert returns the split of the node when it overflows & whether the key is new
+func (b BTree[Key, Value]) insert(nodeRef string, key Key, value Value) (*bTreeSplit[Key], bool, error) {
+	node, err := b.getNode(nodeRef)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	if node.Leaf {
+		index := lowerBound(node.Keys, key)
+		if index < len(node.Keys) && node.Keys[index] == key {
+			node.Values[index] = value
+			return nil, false, b.setNode(nodeRef, node)
+		}
+
+		node.Keys = insertAt(node.Keys, index, key)
+		node.Values = insertAt(node.Values, index, value)
+		split, err := b.splitIfOverflow(nodeRef, node)
+		return split, true, err
+	}
+
+	index := upperBound(node.Keys, key)
+	childSplit, inserted, err := b.insert(node.Children[index], key, value)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	if childSplit == nil {
+		return nil, inserted, nil
+	}
+
+	node.Keys = insertAt(node.Keys, index, childSplit.key)
+	node.Children = insertAt(node.Children, index+1, childSplit.rightRef)
+	split, err := b.splitIfOverflow(nodeRef, node)
+	return split, inserted, err
+}
+
+// splitIfOverflow moves the upper half of an overflowed node to a new right sibling
+func (b BTree[Key, Value]) splitIfOverflow(nodeRef string, node bTreeNode[Key, Value]) (*bTreeSplit[Key], error) {
+	if len(node.Keys) <= b.order {
+		return nil, b.setNode(nodeRef, node)
+	}
+
+	rightRef, err := b.createNodeRef()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	middle := len(node.Keys) / 2
+	var right bTreeNode[Key, Value]
+	var splitKey Key
+	if node.Leaf {
+		right = bTreeNode[Key, Value]{
+			Leaf:   true,
+			Keys:   append([]Key{}, node.Keys[middle:]...),
+			Values: append([]Value{}, node.Values[middle:]...),
+			Prev:   nodeRef,
+			Next:   node.Next,
+		}
+		splitKey = right.Keys[0]
+
+		err = b.relinkPrev(node.Next, rightRef)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		node.Keys = node.Keys[:middle]
+		node.Values = node.Values[:middle]
+		node.Next = rightRef
+	} else {
+		// the middle key moves up to the parent
+		right = bTreeNode[Key, Value]{
+			Keys:     append([]Key{}, node.Keys[middle+1:]...),
+			Children: append([]string{}, node.Children[middle+1:]...),
+		}
+		splitKey = node.Keys[middle]
+
+		node.Keys = node.Keys[:middle]
+		node.Children = node.Children[:middle+1]
+	}
+
+	err = b.setNode(rightRef, right)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	err = b.setNode(nodeRef, node)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &bTreeSplit[Key]{key: splitKey, rightRef: rightRef}, nil
+}
+
+func (b BTree[Key, Value]) delete(nodeRef string, key Key) (bool, error) {
+	node, err := b.getNode(nodeRef)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	if node.Leaf {
+		index := lowerBound(node.Keys, key)
+		if index == len(node.Keys) || node.Keys[index] != key {
+			return false, nil
+		}
+
+		node.Keys = removeAt(node.Keys, index)
+		node.Values = removeAt(node.Values, index)
+		return true, b.setNode(nodeRef, node)
+	}
+
+	index := upperBound(node.Keys, key)
+	deleted, err := b.delete(node.Children[index], key)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+
+	child, err := b.getNode(node.Children[index])
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	if len(child.Keys) >= b.minKeys() {
+		return true, nil
+	}
+
+	err = b.rebalance(&node, index, child)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return true, b.setNode(nodeRef, node)
+}
+
+// rebalance fixes the underflowed child of parent by borrowing a key from a sibling or merging with it
+func (b BTree[Key, Value]) rebalance(parent *bTreeNode[Key, Value], index int, child bTreeNode[Key, Value]) error {
+	childRef := parent.Children[index]
+	if index > 0 {
+		leftRef := parent.Children[index-1]
+		left, err := b.getNode(leftRef)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if len(left.Keys) <= b.minKeys() {
+			return b.merge(parent, index-1, left, child)
+		}
+
+		last := len(left.Keys) - 1
+		if child.Leaf {
+			child.Keys = insertAt(child.Keys, 0, left.Keys[last])
+			child.Values = insertAt(child.Values, 0, left.Values[last])
+			left.Values = left.Values[:last]
+			parent.Keys[index-1] = child.Keys[0]
+		} else {
+			child.Keys = insertAt(child.Keys, 0, parent.Keys[index-1])
+			child.Children = insertAt(child.Children, 0, left.Children[last+1])
+			left.Children = left.Children[:last+1]
+			parent.Keys[index-1] = left.Keys[last]
+		}
+
+		left.Keys = left.Keys[:last]
+		err = b.setNode(leftRef, left)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return b.setNode(childRef, child)
+	}
+
+	rightRef := parent.Children[index+1]
+	right, err := b.getNode(rightRef)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if len(right.Keys) <= b.minKeys() {
+		return b.merge(parent, index, child, right)
+	}
+
+	if child.Leaf {
+		child.Keys = append(child.Keys, right.Keys[0])
+		child.Values = append(child.Values, right.Values[0])
+		right.Values = removeAt(right.Values, 0)
+		right.Keys = removeAt(right.Keys, 0)
+		parent.Keys[index] = right.Keys[0]
+	} else {
+		child.Keys = append(child.Keys, parent.Keys[index])
+		child.Children = append(child.Children, right.Children[0])
+		parent.Keys[index] = right.Keys[0]
+		right.Keys = removeAt(right.Keys, 0)
+		right.Children = removeAt(right.Children, 0)
+	}
+
+	err = b.setNode(rightRef, right)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return b.setNode(childRef, child)
+}
+
+// merge moves the node at index+1 of parent into the node at index
+func (b BTree[Key, Value]) merge(
+	parent *bTreeNode[Key, Value],
+	index int,
+	left bTreeNode[Key, Value],
+	right bTreeNode[Key, Value],
+) error {
+	leftRef := parent.Children[index]
+	rightRef := parent.Children[index+1]
+	if left.Leaf {
+		left.Keys = append(left.Keys, right.Keys...)
+		left.Values = append(left.Values, right.Values...)
+		left.Next = right.Next
+
+		err := b.relinkPrev(right.Next, leftRef)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	} else {
+		left.Keys = append(append(left.Keys, parent.Keys[index]), right.Keys...)
+		left.Children = append(left.Children, right.Children...)
+	}
+
+	parent.Keys = removeAt(parent.Keys, index)
+	parent.Children = removeAt(parent.Children, index+1)
+	err := b.setNode(leftRef, left)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return b.rawMap.Delete(rightRef)
+}
+
+// relinkPrev points the Prev of a leaf to prevRef
+func (b BTree[Key, Value]) relinkPrev(nodeRef string, prevRef string) error {
+	if nodeRef == "" {
+		return nil
+	}
+
+	node, err := b.getNode(nodeRef)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	node.Prev = prevRef
+	return b.setNode(nodeRef, node)
+}
+
+// findLeaf returns the leaf where key is or would be inserted
+func (b BTree[Key, Value]) findLeaf(key Key) (bTreeNode[Key, Value], error) {
+	nodeRef, err := b.getRootRef()
+	if err != nil {
+		log.Println(err)
+		return bTreeNode[Key, Value]{}, err
+	}
+
+	for {
+		node, err := b.getNode(nodeRef)
+		if err != nil {
+			log.Println(err)
+			return bTreeNode[Key, Value]{}, err
+		}
+
+		if node.Leaf {
+			return node, nil
+		}
+
+		nodeRef = node.Children[upperBound(node.Keys, key)]
+	}
+}
+
+// findEdgeLeaf returns the leftmost leaf, or the rightmost one when rightmost is set
+func (b BTree[Key, Value]) findEdgeLeaf(rightmost bool) (bTreeNode[Key, Value], error) {
+	nodeRef, err := b.getRootRef()
+	if err != nil {
+		log.Println(err)
+		return bTreeNode[Key, Value]{}, err
+	}
+
+	for {
+		node, err := b.getNode(nodeRef)
+		if err != nil {
+			log.Println(err)
+			return bTreeNode[Key, Value]{}, err
+		}
+
+		if node.Leaf {
+			return node, nil
+		}
+
+		if rightmost {
+			nodeRef = node.Children[len(node.Children)-1]
+		} else {
+			nodeRef = node.Children[0]
+		}
+	}
+}
+
+func (b BTree[Key, Value]) minKeys() int {
+	return b.order / 2
+}
+
+func (b BTree[Key, Value]) getNode(nodeRef string) (bTreeNode[Key, Value], error) {
+	buf, err := b.rawMap.Get(nodeRef)
+	if err != nil {
+		log.Println(err)
+		return bTreeNode[Key, Value]{}, err
+	}
+
+	var node bTreeNode[Key, Value]
+	err = b.codec.Unmarshal(buf, &node)
+	return node, err
+}
+
+func (b BTree[Key, Value]) setNode(nodeRef string, node bTreeNode[Key, Value]) error {
+	buf, err := b.codec.Marshal(node)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return b.rawMap.Set(nodeRef, buf)
+}
+
+func (b BTree[Key, Value]) createNode(node bTreeNode[Key, Value]) (string, error) {
+	nodeRef, err := b.createNodeRef()
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	return nodeRef, b.setNode(nodeRef, node)
+}
+
+func (b BTree[Key, Value]) createNodeRef() (string, error) {
+	return createBTreeNodeRef(b.refGen, b.storagePath)
+}
+
+func (b BTree[Key, Value]) getRootRef() (string, error) {
+	buf, err := b.rawMap.Get(b.rootPath())
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	var rootRef string
+	err = b.codec.Unmarshal(buf, &rootRef)
+	return rootRef, err
+}
+
+func (b BTree[Key, Value]) setRootRef(rootRef string) error {
+	buf, err := b.codec.Marshal(rootRef)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return b.rawMap.Set(b.rootPath(), buf)
+}
+
+func (b BTree[Key, Value]) addLength(delta int) error {
+	length, err := b.Len()
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return b.addLengthFrom(length, delta)
+}
+
+func (b BTree[Key, Value]) addLengthFrom(length int, delta int) error {
+	buf, err := b.codec.Marshal(length + delta)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return b.rawMap.Set(b.lengthPath(), buf)
+}
+
+func (b BTree[Key, Value]) withRawMap(rawMap storage.RawMap) BTree[Key, Value] {
+	return BTree[Key, Value]{
+		storagePath: b.storagePath,
+		refGen:      b.refGen,
+		rawMap:      rawMap,
+		codec:       b.codec,
+		order:       b.order,
+	}
+}
+
+func (b BTree[Key, Value]) rootPath() string {
+	return path.Join(b.storagePath, "root")
+}
+
+func (b BTree[Key, Value]) lengthPath() string {
+	return path.Join(b.storagePath, "length")
+}
+
+func NewBTree[Key types.Comparable, Value any](
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format Format,
+) (BTree[Key, Value], error) {
+	return newBTree[Key, Value](storagePath, refGen, rawMap, format, defaultBTreeOrder)
+}
+
+// newBTree creates a tree whose nodes hold up to order keys, existing trees keep the order they were created with
+func newBTree[Key types.Comparable, Value any](
+	storagePath string,
+	refGen *idgen.IDGen,
+	rawMap storage.RawMap,
+	format Format,
+	order int,
+) (BTree[Key, Value], error) {
+	if order < 3 {
+		return BTree[Key, Value]{}, fmt.Errorf("B+ tree order too small: %v", order)
+	}
+
+	var codec Codec
+	err := storage.RunInBatch(rawMap, func(batch storage.RawMap) error {
+		orderPath := path.Join(storagePath, "order")
+		rootPath := path.Join(storagePath, "root")
+		contain, err := batch.Contain(rootPath)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		codec, err = loadCodec(storagePath, batch, !contain, format)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if contain {
+			buf, err := batch.Get(orderPath)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+
+			return codec.Unmarshal(buf, &order)
+		}
+
+		tree := BTree[Key, Value]{
+			storagePath: storagePath,
+			refGen:      refGen,
+			rawMap:      batch,
+			codec:       codec,
+			order:       order,
+		}
+		buf, err := codec.Marshal(order)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = batch.Set(orderPath, buf)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		rootRef, err := tree.createNode(bTreeNode[Key, Value]{Leaf: true})
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = tree.setRootRef(rootRef)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return tree.addLengthFrom(0, 0)
+	})
+	if err != nil {
+		return BTree[Key, Value]{}, err
+	}
+
+	return BTree[Key, Value]{
+		storagePath: storagePath,
+		refGen:      refGen,
+		rawMap:      rawMap,
+		codec:       codec,
+		order:       order,
+	}, nil
+}
+
+func createBTreeNodeRef(refGen *idgen.IDGen, treePath string) (string, error) {
+	ref, err := refGen.NextID()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(treePath, "nodes", strconv.FormatUint(ref, 10)), nil
+}
+
+// lowerBound returns the index of the first key larger than or equal to key
+func lowerBound[Key types.Comparable](keys []Key, key Key) int {
+	return sort.Search(len(keys), func(index int) bool {
+		return keys[index] >= key
+	})
+}
+
+// upperBound returns the index of the first key larger than key
+func upperBound[Key types.Comparable](keys []Key, key Key) int {
+	return sort.Search(len(keys), func(index int) bool {
+		return keys[index] > key
+	})
+}
+
+// splitEvenly splits count items into the fewest groups of at most maxSize items with sizes differing by at most 1
+func splitEvenly(count int, maxSize int) []int {
+	groupCount := (count + maxSize - 1) / maxSize
+	sizes := make([]int, groupCount)
+	for index := range sizes {
+		sizes[index] = count / groupCount
+		if index < count%groupCount {
+			sizes[index]++
+		}
+	}
+
+	return sizes
+}
+
+func insertAt[Item any](items []Item, index int, item Item) []Item {
+	items = append(items, item)
+	copy(items[index+1:], items[index:])
+	items[index] = item
+	return items
+}
+
+func removeAt[Item any](items []Item, index int) []Item {
+	return append(items[:index], items[index+1:]...)
+}
@@ -0,0 +1,94 @@
+package reliable
+
+import (
+	"log"
+
+	"tstore/types"
+)
+
+// BTreeIterator lazily walks through the entries of a BTree along the linked leaves, loading one leaf at a time.
+//
+//	for iterator.Next() {
+//		iterator.Key(), iterator.Value()
+//	}
+//	err := iterator.Err()
+//
+// Close stops the iteration early, after which Next always returns false.
+type BTreeIterator[Key types.Comparable, Value any] struct {
+	tree    *BTree[Key, Value]
+	reverse bool
+	// seek finds the leaf & the index of the 1st entry
+	seek    func() (bTreeNode[Key, Value], int, error)
+	started bool
+	node    bTreeNode[Key, Value]
+	index   int
+	key     Key
+	value   Value
+	err     error
+	closed  bool
+}
+
+func (b *BTreeIterator[Key, Value]) Next() bool {
+	if b.closed || b.err != nil {
+		return false
+	}
+
+	if !b.started {
+		b.started = true
+		b.node, b.index, b.err = b.seek()
+		if b.err != nil {
+			log.Println(b.err)
+			return false
+		}
+	}
+
+	for b.index < 0 || b.index >= len(b.node.Keys) {
+		nextRef := b.node.Next
+		if b.reverse {
+			nextRef = b.node.Prev
+		}
+
+		if nextRef == "" {
+			b.closed = true
+			return false
+		}
+
+		b.node, b.err = b.tree.getNode(nextRef)
+		if b.err != nil {
+			log.Println(b.err)
+			return false
+		}
+
+		b.index = 0
+		if b.reverse {
+			b.index = len(b.node.Keys) - 1
+		}
+	}
+
+	b.key = b.node.Keys[b.index]
+	b.value = b.node.Values[b.index]
+	if b.reverse {
+		b.index--
+	} else {
+		b.index++
+	}
+
+	return true
+}
+
+func (b *BTreeIterator[Key, Value]) Key() Key {
+	return b.key
+}
+
+func (b *BTreeIterator[Key, Value]) Value() Value {
+	return b.value
+}
+
+func (b *BTreeIterator[Key, Value]) Err() error {
+	return b.err
+}
+
+func (b *BTreeIterator[Key, Value]) Close() error {
+	b.closed = true
+	return nil
+}
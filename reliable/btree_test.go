@@ -0,0 +1,320 @@
+package reliable
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"tstore/idgen"
+	"tstore/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testBTreeOrder = 4
+
+func TestBTree(t *testing.T) {
+	tree, _ := newTestBTree(t, storage.NewInMemoryMap(), JSONFormat)
+	for key := 1; key <= 20; key++ {
+		assert.Nil(t, tree.Put(key, fmt.Sprint(key)))
+	}
+
+	assert.Nil(t, tree.Put(7, "seven"))
+	value, ok, err := tree.Get(7)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "seven", value)
+
+	deleted, err := tree.Delete(8)
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = tree.Delete(8)
+	assert.Nil(t, err)
+	assert.False(t, deleted)
+
+	_, ok, err = tree.Get(8)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	length, err := tree.Len()
+	assert.Nil(t, err)
+	assert.Equal(t, 19, length)
+
+	assert.Equal(t, []int{9, 10, 11}, collectKeys(tree.AscendFrom(8), 3))
+	assert.Equal(t, []int{7, 6, 5}, collectKeys(tree.DescendFrom(8), 3))
+	assert.Equal(t, []int{20, 19}, collectKeys(tree.Descend(), 2))
+	assert.Equal(t, []int{}, collectKeys(tree.AscendFrom(21), -1))
+	assert.Equal(t, []int{}, collectKeys(tree.DescendFrom(0), -1))
+}
+
+func TestBTree_BulkLoad(t *testing.T) {
+	for _, count := range []int{0, 1, testBTreeOrder, testBTreeOrder + 1, 100} {
+		tree, _ := newTestBTree(t, storage.NewInMemoryMap(), JSONFormat)
+		reference := make(map[int]string)
+		entries := make([]BTreeEntry[int, string], 0)
+		for key := 0; key < count; key++ {
+			entries = append(entries, BTreeEntry[int, string]{Key: key * 2, Value: fmt.Sprint(key)})
+			reference[key*2] = fmt.Sprint(key)
+		}
+
+		assert.Nil(t, tree.BulkLoad(entries))
+		assert.Nil(t, checkBTree(tree, reference))
+
+		// the loaded tree keeps working
+		for key := 0; key < count; key += 3 {
+			assert.Nil(t, tree.Put(key*2+1, "odd"))
+			reference[key*2+1] = "odd"
+
+			_, err := tree.Delete(key * 2)
+			assert.Nil(t, err)
+			delete(reference, key*2)
+		}
+
+		assert.Nil(t, checkBTree(tree, reference))
+	}
+
+	tree, _ := newTestBTree(t, storage.NewInMemoryMap(), JSONFormat)
+	assert.NotNil(t, tree.BulkLoad([]BTreeEntry[int, string]{{Key: 2}, {Key: 1}}))
+	assert.Nil(t, tree.Put(1, "1"))
+	assert.NotNil(t, tree.BulkLoad([]BTreeEntry[int, string]{{Key: 2}}))
+}
+
+// TestBTree_Property applies random puts & deletes to the tree and to a map, then compares them.
+func TestBTree_Property(t *testing.T) {
+	for _, format := range []Format{JSONFormat, BinaryFormat} {
+		t.Run(format.String(), func(t *testing.T) {
+			property := func(seed int64) bool {
+				random := rand.New(rand.NewSource(seed))
+				tree, _ := newTestBTree(t, storage.NewInMemoryMap(), format)
+				reference := make(map[int]string)
+				for op := 0; op < 300; op++ {
+					key := random.Intn(100)
+					if random.Intn(3) == 0 {
+						_, err := tree.Delete(key)
+						if err != nil {
+							t.Log(err)
+							return false
+						}
+
+						delete(reference, key)
+						continue
+					}
+
+					value := fmt.Sprint(random.Int())
+					err := tree.Put(key, value)
+					if err != nil {
+						t.Log(err)
+						return false
+					}
+
+					reference[key] = value
+				}
+
+				err := checkBTree(tree, reference)
+				if err != nil {
+					t.Log(err)
+				}
+
+				return err == nil
+			}
+
+			assert.Nil(t, quick.Check(property, &quick.Config{MaxCount: 30, Rand: rand.New(rand.NewSource(1))}))
+		})
+	}
+}
+
+// TestBTree_Faults verifies a failed or crashed operation leaves the tree as it was before the operation.
+func TestBTree_Faults(t *testing.T) {
+	property := func(seed int64) bool {
+		random := rand.New(rand.NewSource(seed))
+		rawMap := storage.NewInMemoryMap()
+		_, refGen := newTestBTree(t, rawMap, JSONFormat)
+		faultyMap := storage.NewFaultyMap(rawMap, storage.RandomFaultPlan(seed, 0.05, 0))
+		tree, err := newBTree[int, string]("tree", refGen, faultyMap, JSONFormat, testBTreeOrder)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		crashAt := 100 + random.Intn(200)
+		reference := make(map[int]string)
+		for op := 0; op < 300; op++ {
+			if op == crashAt {
+				faultyMap = storage.NewFaultyMap(rawMap, storage.CrashPlan(0))
+				tree = tree.withRawMap(faultyMap)
+			}
+
+			key := random.Intn(100)
+			if random.Intn(3) == 0 {
+				_, err = tree.Delete(key)
+				if err == nil && !faultyMap.Crashed() {
+					delete(reference, key)
+				}
+
+				continue
+			}
+
+			value := fmt.Sprint(random.Int())
+			err = tree.Put(key, value)
+			if err == nil && !faultyMap.Crashed() {
+				reference[key] = value
+			}
+		}
+
+		tree, err = newBTree[int, string]("tree", refGen, rawMap, JSONFormat, testBTreeOrder)
+		if err == nil {
+			err = checkBTree(tree, reference)
+		}
+
+		if err != nil {
+			t.Log(err)
+		}
+
+		return err == nil
+	}
+
+	assert.Nil(t, quick.Check(property, &quick.Config{MaxCount: 20, Rand: rand.New(rand.NewSource(1))}))
+}
+
+func newTestBTree(t *testing.T, rawMap storage.RawMap, format Format) (BTree[int, string], *idgen.IDGen) {
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	tree, err := newBTree[int, string]("tree", refGen, rawMap, format, testBTreeOrder)
+	assert.Nil(t, err)
+	return tree, refGen
+}
+
+// collectKeys returns up to limit keys, a negative limit collects all of them
+func collectKeys(iterator *BTreeIterator[int, string], limit int) []int {
+	defer iterator.Close()
+
+	keys := make([]int, 0)
+	for len(keys) != limit && iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	return keys
+}
+
+// checkBTree compares the tree with the reference and verifies the invariants of a B+ tree
+func checkBTree(tree BTree[int, string], reference map[int]string) error {
+	keys := make([]int, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+
+	length, err := tree.Len()
+	if err != nil {
+		return err
+	}
+
+	if length != len(keys) {
+		return fmt.Errorf("length mismatch: expected=%v actual=%v", len(keys), length)
+	}
+
+	for _, key := range keys {
+		value, ok, err := tree.Get(key)
+		if err != nil {
+			return err
+		}
+
+		if !ok || value != reference[key] {
+			return fmt.Errorf("value mismatch: key=%v expected=%v actual=%v", key, reference[key], value)
+		}
+	}
+
+	ascending := collectKeys(tree.Ascend(), -1)
+	if fmt.Sprint(ascending) != fmt.Sprint(keys) {
+		return fmt.Errorf("ascend mismatch: expected=%v actual=%v", keys, ascending)
+	}
+
+	descending := collectKeys(tree.Descend(), -1)
+	for index, key := range descending {
+		if key != keys[len(keys)-1-index] {
+			return fmt.Errorf("descend mismatch: expected=%v actual=%v", keys, descending)
+		}
+	}
+
+	for _, start := range []int{-1, 0, 33, 50, 99, 100} {
+		from := sort.SearchInts(keys, start)
+		ascending = collectKeys(tree.AscendFrom(start), -1)
+		if fmt.Sprint(ascending) != fmt.Sprint(keys[from:]) {
+			return fmt.Errorf("ascend from %v mismatch: expected=%v actual=%v", start, keys[from:], ascending)
+		}
+
+		to := sort.SearchInts(keys, start+1)
+		descending = collectKeys(tree.DescendFrom(start), -1)
+		if len(descending) != to {
+			return fmt.Errorf("descend from %v mismatch: expected=%v actual=%v", start, keys[:to], descending)
+		}
+	}
+
+	rootRef, err := tree.getRootRef()
+	if err != nil {
+		return err
+	}
+
+	_, err = checkBTreeNode(tree, rootRef, true, nil, nil)
+	return err
+}
+
+// checkBTreeNode verifies the keys of the node are sorted within [low, high) and returns the depth of its leaves
+func checkBTreeNode(tree BTree[int, string], nodeRef string, isRoot bool, low *int, high *int) (int, error) {
+	node, err := tree.getNode(nodeRef)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(node.Keys) > tree.order || (!isRoot && len(node.Keys) < tree.minKeys()) {
+		return 0, fmt.Errorf("invalid node size: node=%v keys=%v", nodeRef, node.Keys)
+	}
+
+	for index, key := range node.Keys {
+		if (index > 0 && node.Keys[index-1] >= key) || (low != nil && key < *low) || (high != nil && key >= *high) {
+			return 0, fmt.Errorf("invalid key order: node=%v keys=%v", nodeRef, node.Keys)
+		}
+	}
+
+	if node.Leaf {
+		if len(node.Values) != len(node.Keys) {
+			return 0, fmt.Errorf("value count mismatch: node=%v", nodeRef)
+		}
+
+		return 1, nil
+	}
+
+	if len(node.Children) != len(node.Keys)+1 {
+		return 0, fmt.Errorf("child count mismatch: node=%v", nodeRef)
+	}
+
+	depth := -1
+	for index, childRef := range node.Children {
+		childLow, childHigh := low, high
+		if index > 0 {
+			childLow = &node.Keys[index-1]
+		}
+
+		if index < len(node.Keys) {
+			childHigh = &node.Keys[index]
+		}
+
+		childDepth, err := checkBTreeNode(tree, childRef, false, childLow, childHigh)
+		if err != nil {
+			return 0, err
+		}
+
+		if depth >= 0 && childDepth != depth {
+			return 0, fmt.Errorf("unbalanced node: node=%v", nodeRef)
+		}
+
+		depth = childDepth
+	}
+
+	return depth + 1, nil
+}
@@ -0,0 +1,122 @@
+package reliable
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+
+	"tstore/storage"
+)
+
+// Format identifies the Codec used to encode the items of a collection.
+type Format byte
+
+const (
+	JSONFormat   Format = 0
+	BinaryFormat Format = 1
+)
+
+type Codec interface {
+	Format() Format
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(buf []byte, value interface{}) error
+}
+
+var (
+	codecs = map[Format]Codec{
+		JSONFormat:   JSONCodec{},
+		BinaryFormat: BinaryCodec{},
+	}
+	formatNames = map[Format]string{
+		JSONFormat:   "json",
+		BinaryFormat: "binary",
+	}
+)
+
+func ParseFormat(name string) (Format, error) {
+	for format, currName := range formatNames {
+		if currName == name {
+			return format, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown format: %v", name)
+}
+
+func (f Format) String() string {
+	name, ok := formatNames[f]
+	if !ok {
+		return fmt.Sprintf("format(%d)", byte(f))
+	}
+
+	return name
+}
+
+func getCodec(format Format) (Codec, error) {
+	codec, ok := codecs[format]
+	if !ok {
+		return nil, fmt.Errorf("codec not found: %v", format)
+	}
+
+	return codec, nil
+}
+
+// loadCodec returns the codec recorded in the format marker of a collection.
+// Collections without the marker are created with the format when they are new,
+// otherwise they were written before formats existed and use JSON.
+func loadCodec(storagePath string, rawMap storage.RawMap, isNew bool, format Format) (Codec, error) {
+	formatPath := path.Join(storagePath, "format")
+	contain, err := rawMap.Contain(formatPath)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if contain {
+		buf, err := rawMap.Get(formatPath)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if len(buf) != 1 {
+			return nil, fmt.Errorf("invalid format marker: path=%v", formatPath)
+		}
+
+		return getCodec(Format(buf[0]))
+	}
+
+	if !isNew {
+		return JSONCodec{}, nil
+	}
+
+	codec, err := getCodec(format)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	err = rawMap.Set(formatPath, []byte{byte(codec.Format())})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return codec, nil
+}
+
+type JSONCodec struct {
+}
+
+func (j JSONCodec) Format() Format {
+	return JSONFormat
+}
+
+func (j JSONCodec) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (j JSONCodec) Unmarshal(buf []byte, value interface{}) error {
+	return json.Unmarshal(buf, value)
+}
@@ -0,0 +1,134 @@
+package reliable
+
+import (
+	"math"
+	"path"
+	"testing"
+	"time"
+
+	"tstore/idgen"
+	"tstore/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codecTestItem struct {
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes"`
+	Tags       []string               `json:"tags"`
+	Parent     *codecTestItem         `json:"parent"`
+	internal   int
+}
+
+func TestBinaryCodec(t *testing.T) {
+	codec := BinaryCodec{}
+	createdAt := time.Date(2022, 5, 17, 8, 30, 15, 123456789, time.FixedZone("", 8*60*60))
+	values := []interface{}{
+		nil,
+		true,
+		int(-42),
+		int8(math.MinInt8),
+		int16(math.MaxInt16),
+		int64(math.MinInt64),
+		uint8(math.MaxUint8),
+		uint32(math.MaxUint32),
+		uint64(math.MaxUint64),
+		float32(1.5),
+		3.14159,
+		"Harry",
+		'H',
+		createdAt,
+		[]byte{0, 1, 2},
+		[]interface{}{1, "2", 3.0},
+		map[string]interface{}{"id": uint64(1), "initial": 'P'},
+	}
+
+	for _, value := range values {
+		buf, err := codec.Marshal(value)
+		assert.Nil(t, err)
+
+		var decoded interface{}
+		assert.Nil(t, codec.Unmarshal(buf, &decoded))
+		assert.Equal(t, value, decoded)
+	}
+
+	item := codecTestItem{
+		Name: "Harry",
+		Attributes: map[string]interface{}{
+			"age":       18,
+			"initial":   'H',
+			"createdAt": createdAt,
+			"score":     99.5,
+		},
+		Tags:     []string{"student"},
+		Parent:   &codecTestItem{Name: "James"},
+		internal: 1,
+	}
+	buf, err := codec.Marshal(item)
+	assert.Nil(t, err)
+
+	var decodedItem codecTestItem
+	assert.Nil(t, codec.Unmarshal(buf, &decodedItem))
+	item.internal = 0
+	assert.Equal(t, item, decodedItem)
+
+	// fields unknown to the struct are skipped
+	type olderItem struct {
+		Name string `json:"name"`
+	}
+	var older olderItem
+	assert.Nil(t, codec.Unmarshal(buf, &older))
+	assert.Equal(t, "Harry", older.Name)
+
+	var number uint8
+	buf, err = codec.Marshal(300)
+	assert.Nil(t, err)
+	assert.NotNil(t, codec.Unmarshal(buf, &number))
+	assert.NotNil(t, codec.Unmarshal(buf[:len(buf)-1], &number))
+}
+
+func TestList_Format(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	jsonList, err := NewList[interface{}]("jsonList", refGen, rawMap, JSONFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, jsonList.Append(uint64(1)))
+
+	// collections written before the format marker are read as JSON
+	assert.Nil(t, rawMap.Delete(path.Join("jsonList", "format")))
+
+	jsonList, err = NewList[interface{}]("jsonList", refGen, rawMap, BinaryFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, jsonList.Append(uint64(2)))
+
+	items, err := jsonList.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, items)
+
+	binaryList, err := NewList[interface{}]("binaryList", refGen, rawMap, BinaryFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, binaryList.Append(uint64(1)))
+	assert.Nil(t, binaryList.Append('R'))
+
+	items, err = binaryList.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{uint64(1), 'R'}, items)
+
+	binaryMap, err := NewMap[string, interface{}]("binaryMap", refGen, rawMap, BinaryFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, binaryMap.Set("count", int16(7)))
+
+	// an existing collection keeps its format
+	binaryMap, err = NewMap[string, interface{}]("binaryMap", refGen, rawMap, JSONFormat)
+	assert.Nil(t, err)
+
+	value, err := binaryMap.Get("count")
+	assert.Nil(t, err)
+	assert.Equal(t, int16(7), value)
+
+	keys, err := binaryMap.Keys()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"count"}, keys)
+}
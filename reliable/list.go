@@ -1,7 +1,6 @@
 package reliable
 
 import (
-	"encoding/json"
 	"errors"
 	"log"
 	"path"
@@ -15,11 +14,15 @@ type List[Item any] struct {
 	storagePath string
 	refGen      *idgen.IDGen
 	rawMap      storage.RawMap
+	codec       Codec
 }
 
 func (l *List[Item]) Append(item Item) error {
-	_, err := l.append(item)
-	return err
+	return storage.RunInBatch(l.rawMap, func(batch storage.RawMap) error {
+		list := l.withRawMap(batch)
+		_, err := list.append(item)
+		return err
+	})
 }
 
 func (l *List[Item]) Peek() (Item, error) {
@@ -47,7 +50,7 @@ func (l *List[Item]) Peek() (Item, error) {
 	}
 
 	var item Item
-	err = json.Unmarshal(buf, &item)
+	err = l.codec.Unmarshal(buf, &item)
 	return item, err
 }
 
@@ -70,11 +73,22 @@ func (l *List[Item]) Length() (int, error) {
 	}
 
 	var length int
-	err = json.Unmarshal(buf, &length)
+	err = l.codec.Unmarshal(buf, &length)
 	return length, err
 }
 
 func (l *List[Item]) Pop() (Item, error) {
+	var item Item
+	err := storage.RunInBatch(l.rawMap, func(batch storage.RawMap) error {
+		list := l.withRawMap(batch)
+		var err error
+		item, err = list.pop()
+		return err
+	})
+	return item, err
+}
+
+func (l *List[Item]) pop() (Item, error) {
 	item, err := l.Peek()
 	if err != nil {
 		log.Println(err)
@@ -99,6 +113,12 @@ func (l *List[Item]) Pop() (Item, error) {
 		return *new(Item), err
 	}
 
+	err = l.setNodeRefPath(l.tailPath(), nodePrevPath)
+	if err != nil {
+		log.Println(err)
+		return *new(Item), err
+	}
+
 	err = l.decrementLength()
 	if err != nil {
 		log.Println(err)
@@ -108,49 +128,62 @@ func (l *List[Item]) Pop() (Item, error) {
 	return item, l.rawMap.Delete(tailNodeRefPath)
 }
 
-func (l List[Item]) Items() ([]Item, error) {
-	// TODO: use iterator instead
-	dummyPath := path.Join(l.storagePath, "dummy")
-	buf, err := l.rawMap.Get(dummyPath)
+// Shift removes the head of the list.
+func (l *List[Item]) Shift() (Item, error) {
+	var item Item
+	err := storage.RunInBatch(l.rawMap, func(batch storage.RawMap) error {
+		list := l.withRawMap(batch)
+		var err error
+		item, err = list.shift()
+		return err
+	})
+	return item, err
+}
+
+func (l *List[Item]) shift() (Item, error) {
+	dummyNodeRef, err := l.getNodeRefPath(path.Join(l.storagePath, "dummy"))
 	if err != nil {
 		log.Println(err)
-		return nil, err
+		return *new(Item), err
 	}
 
-	var prevPath string
-	err = json.Unmarshal(buf, &prevPath)
+	headRefPath := path.Join(dummyNodeRef, "next")
+	contain, err := l.rawMap.Contain(headRefPath)
 	if err != nil {
-		return nil, err
+		log.Println(err)
+		return *new(Item), err
 	}
 
-	items := make([]Item, 0)
+	if !contain {
+		return *new(Item), errors.New("list must have at least 1 item")
+	}
 
-	for true {
-		nextPath := path.Join(prevPath, "next")
-		contains, err := l.rawMap.Contain(nextPath)
-		if err != nil {
-			log.Println(err)
-			return nil, err
-		}
+	headNodeRef, err := l.getNodeRefPath(headRefPath)
+	if err != nil {
+		log.Println(err)
+		return *new(Item), err
+	}
 
-		if !contains {
-			break
-		}
+	item, err := l.getItem(headNodeRef)
+	if err != nil {
+		log.Println(err)
+		return *new(Item), err
+	}
 
-		currNodeRef, err := l.getNodeRefPath(nextPath)
-		if err != nil {
-			log.Println(err)
-			return nil, err
-		}
+	return item, l.delete(headNodeRef)
+}
 
-		item, err := l.getItem(currNodeRef)
-		if err != nil {
-			log.Println(err)
-			return nil, err
-		}
+func (l List[Item]) Items() ([]Item, error) {
+	iterator := l.Iterator()
+	defer iterator.Close()
 
-		items = append(items, item)
-		prevPath = currNodeRef
+	items := make([]Item, 0)
+	for iterator.Next() {
+		items = append(items, iterator.Item())
+	}
+
+	if iterator.Err() != nil {
+		return nil, iterator.Err()
 	}
 
 	return items, nil
@@ -163,7 +196,7 @@ func (l *List[Item]) append(item Item) (string, error) {
 		return "", err
 	}
 
-	buf, err := json.Marshal(item)
+	buf, err := l.codec.Marshal(item)
 	if err != nil {
 		log.Println(err)
 		return "", err
@@ -190,7 +223,7 @@ func (l *List[Item]) append(item Item) (string, error) {
 		return "", err
 	}
 
-	nodeRefBuf, err := json.Marshal(nodeRefPath)
+	nodeRefBuf, err := l.codec.Marshal(nodeRefPath)
 	if err != nil {
 		log.Println(err)
 		return "", err
@@ -203,7 +236,7 @@ func (l *List[Item]) append(item Item) (string, error) {
 	}
 
 	var tailNodeRef string
-	err = json.Unmarshal(tailRefBuf, &tailNodeRef)
+	err = l.codec.Unmarshal(tailRefBuf, &tailNodeRef)
 	if err != nil {
 		log.Println(err)
 		return "", err
@@ -222,7 +255,7 @@ func (l *List[Item]) append(item Item) (string, error) {
 		return "", err
 	}
 
-	buf, err = json.Marshal(length + 1)
+	buf, err = l.codec.Marshal(length + 1)
 	if err != nil {
 		log.Println(err)
 		return "", err
@@ -249,34 +282,57 @@ func (l *List[Item]) delete(nodePath string) error {
 	}
 
 	var nodePrevPath string
-	err = json.Unmarshal(prevBuf, &nodePrevPath)
-	if err != nil {
-		log.Println(err)
-	}
-
-	nextBuf, err := l.rawMap.Get(path.Join(nodePath, "next"))
+	err = l.codec.Unmarshal(prevBuf, &nodePrevPath)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	var nodeNextPath string
-	err = json.Unmarshal(prevBuf, &nodePrevPath)
+	nodeNextRefPath := path.Join(nodePath, "next")
+	hasNext, err := l.rawMap.Contain(nodeNextRefPath)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	err = l.rawMap.Set(path.Join(nodePrevPath, "next"), nextBuf)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
+	if !hasNext {
+		// the node is the tail
+		err = l.rawMap.Delete(path.Join(nodePrevPath, "next"))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
 
-	err = l.rawMap.Set(path.Join(nodeNextPath, "prev"), prevBuf)
-	if err != nil {
-		log.Println(err)
-		return err
+		err = l.rawMap.Set(l.tailPath(), prevBuf)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	} else {
+		nextBuf, err := l.rawMap.Get(nodeNextRefPath)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		var nodeNextPath string
+		err = l.codec.Unmarshal(nextBuf, &nodeNextPath)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = l.rawMap.Set(path.Join(nodePrevPath, "next"), nextBuf)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = l.rawMap.Set(path.Join(nodeNextPath, "prev"), prevBuf)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
 	}
 
 	err = l.decrementLength()
@@ -295,7 +351,7 @@ func (l *List[Item]) decrementLength() error {
 		return err
 	}
 
-	buf, err := json.Marshal(length - 1)
+	buf, err := l.codec.Marshal(length - 1)
 	if err != nil {
 		log.Println(err)
 		return err
@@ -318,7 +374,7 @@ func (l *List[Item]) getNodeRefPath(refPath string) (string, error) {
 	}
 
 	var nodeRefPath string
-	err = json.Unmarshal(buf, &nodeRefPath)
+	err = l.codec.Unmarshal(buf, &nodeRefPath)
 	if err != nil {
 		log.Println(err)
 	}
@@ -326,6 +382,25 @@ func (l *List[Item]) getNodeRefPath(refPath string) (string, error) {
 	return nodeRefPath, err
 }
 
+func (l *List[Item]) setNodeRefPath(refPath string, nodeRefPath string) error {
+	buf, err := l.codec.Marshal(nodeRefPath)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return l.rawMap.Set(refPath, buf)
+}
+
+func (l *List[Item]) withRawMap(rawMap storage.RawMap) *List[Item] {
+	return &List[Item]{
+		storagePath: l.storagePath,
+		refGen:      l.refGen,
+		rawMap:      rawMap,
+		codec:       l.codec,
+	}
+}
+
 func (l *List[Item]) lengthPath() string {
 	return path.Join(l.storagePath, "length")
 }
@@ -347,12 +422,17 @@ func (l *List[Item]) getItem(nodeRefPath string) (Item, error) {
 	}
 
 	var item Item
-	err = json.Unmarshal(buf, &item)
+	err = l.codec.Unmarshal(buf, &item)
 	return item, err
 }
 
-func NewList[Item any](storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap) (List[Item], error) {
-	err := initRefs[Item](storagePath, refGen, rawMap)
+func NewList[Item any](storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap, format Format) (List[Item], error) {
+	var codec Codec
+	err := storage.RunInBatch(rawMap, func(batch storage.RawMap) error {
+		var err error
+		codec, err = initRefs[Item](storagePath, refGen, batch, format)
+		return err
+	})
 	if err != nil {
 		return List[Item]{}, err
 	}
@@ -361,45 +441,54 @@ func NewList[Item any](storagePath string, refGen *idgen.IDGen, rawMap storage.R
 		storagePath: storagePath,
 		refGen:      refGen,
 		rawMap:      rawMap,
+		codec:       codec,
 	}, nil
 }
 
-func initRefs[Item any](storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap) error {
+func initRefs[Item any](storagePath string, refGen *idgen.IDGen, rawMap storage.RawMap, format Format) (Codec, error) {
 	tailPath := path.Join(storagePath, "tail")
 	contains, err := rawMap.Contain(tailPath)
 	if err != nil {
 		log.Println(err)
-		return err
+		return nil, err
+	}
+
+	codec, err := loadCodec(storagePath, rawMap, !contains, format)
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
 
 	if contains {
-		return nil
+		return codec, nil
 	}
 
 	nodeRefPath, err := createNode(refGen, storagePath)
 	if err != nil {
 		log.Println(err)
-		return err
+		return nil, err
 	}
 
-	refBuf, err := json.Marshal(nodeRefPath)
+	refBuf, err := codec.Marshal(nodeRefPath)
 	if err != nil {
 		log.Println(err)
-		return err
+		return nil, err
 	}
 
 	err = rawMap.Set(tailPath, refBuf)
 	if err != nil {
 		log.Println(err)
+		return nil, err
 	}
 
 	dummyPath := path.Join(storagePath, "dummy")
 	err = rawMap.Set(dummyPath, refBuf)
 	if err != nil {
 		log.Println(err)
+		return nil, err
 	}
 
-	return err
+	return codec, nil
 }
 
 func createNode(refGen *idgen.IDGen, listPath string) (string, error) {
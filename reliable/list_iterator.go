@@ -0,0 +1,117 @@
+package reliable
+
+import (
+	"log"
+	"path"
+)
+
+// ListIterator lazily walks through the items of a List, loading one node at a time.
+//
+//	for iterator.Next() {
+//		iterator.Item()
+//	}
+//	err := iterator.Err()
+//
+// Close stops the iteration early, after which Next always returns false.
+type ListIterator[Item any] struct {
+	list    *List[Item]
+	reverse bool
+	// dummyNodeRef is the node before the head of the list
+	dummyNodeRef string
+	// nodeRef is the node of the current item, empty before the 1st call to Next
+	nodeRef string
+	item    Item
+	err     error
+	closed  bool
+}
+
+func (l *ListIterator[Item]) Next() bool {
+	if l.closed || l.err != nil {
+		return false
+	}
+
+	nodeRef, ok, err := l.nextNodeRef()
+	if err != nil {
+		log.Println(err)
+		l.err = err
+		return false
+	}
+
+	if !ok {
+		l.closed = true
+		return false
+	}
+
+	item, err := l.list.getItem(nodeRef)
+	if err != nil {
+		log.Println(err)
+		l.err = err
+		return false
+	}
+
+	l.nodeRef = nodeRef
+	l.item = item
+	return true
+}
+
+func (l *ListIterator[Item]) Item() Item {
+	return l.item
+}
+
+func (l *ListIterator[Item]) Err() error {
+	return l.err
+}
+
+func (l *ListIterator[Item]) Close() error {
+	l.closed = true
+	return nil
+}
+
+func (l *ListIterator[Item]) nextNodeRef() (string, bool, error) {
+	if l.dummyNodeRef == "" {
+		dummyNodeRef, err := l.list.getNodeRefPath(path.Join(l.list.storagePath, "dummy"))
+		if err != nil {
+			return "", false, err
+		}
+
+		l.dummyNodeRef = dummyNodeRef
+	}
+
+	if l.reverse {
+		refPath := l.list.tailPath()
+		if l.nodeRef != "" {
+			refPath = path.Join(l.nodeRef, "prev")
+		}
+
+		nodeRef, err := l.list.getNodeRefPath(refPath)
+		if err != nil {
+			return "", false, err
+		}
+
+		return nodeRef, nodeRef != l.dummyNodeRef, nil
+	}
+
+	prevNodeRef := l.nodeRef
+	if prevNodeRef == "" {
+		prevNodeRef = l.dummyNodeRef
+	}
+
+	nextPath := path.Join(prevNodeRef, "next")
+	contain, err := l.list.rawMap.Contain(nextPath)
+	if err != nil || !contain {
+		return "", false, err
+	}
+
+	nodeRef, err := l.list.getNodeRefPath(nextPath)
+	return nodeRef, true, err
+}
+
+// Iterator walks from the head to the tail of the list.
+func (l List[Item]) Iterator() *ListIterator[Item] {
+	return &ListIterator[Item]{list: &l}
+}
+
+// ReverseIterator walks from the tail to the head of the list.
+func (l List[Item]) ReverseIterator() *ListIterator[Item] {
+	return &ListIterator[Item]{list: &l, reverse: true}
+}
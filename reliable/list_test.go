@@ -0,0 +1,102 @@
+package reliable
+
+import (
+	"path"
+	"testing"
+
+	"tstore/idgen"
+	"tstore/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListIterator(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	list, err := NewList[int]("list", refGen, rawMap, JSONFormat)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{}, collect(list.Iterator()))
+	assert.Equal(t, []int{}, collect(list.ReverseIterator()))
+
+	for item := 1; item <= 5; item++ {
+		assert.Nil(t, list.Append(item))
+	}
+
+	_, err = list.Pop()
+	assert.Nil(t, err)
+
+	assert.Equal(t, []int{1, 2, 3, 4}, collect(list.Iterator()))
+	assert.Equal(t, []int{4, 3, 2, 1}, collect(list.ReverseIterator()))
+
+	iterator := list.ReverseIterator()
+	assert.True(t, iterator.Next())
+	assert.Equal(t, 4, iterator.Item())
+	assert.Nil(t, iterator.Close())
+	assert.False(t, iterator.Next())
+	assert.Nil(t, iterator.Err())
+
+	reliableMap, err := NewMap[string, int]("map", refGen, rawMap, JSONFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, reliableMap.Set("b", 1))
+	assert.Nil(t, reliableMap.Set("a", 2))
+	assert.Nil(t, reliableMap.Set("c", 3))
+	assert.Nil(t, reliableMap.Delete("a"))
+
+	keys := make([]string, 0)
+	keyIterator := reliableMap.KeyIterator()
+	for keyIterator.Next() {
+		keys = append(keys, keyIterator.Item())
+	}
+
+	assert.Nil(t, keyIterator.Err())
+	assert.Equal(t, []string{"b", "c"}, keys)
+}
+
+func TestList_Shift(t *testing.T) {
+	rawMap := storage.NewInMemoryMap()
+	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 10)
+	assert.Nil(t, err)
+
+	list, err := NewList[int]("list", refGen, rawMap, JSONFormat)
+	assert.Nil(t, err)
+
+	_, err = list.Shift()
+	assert.NotNil(t, err)
+
+	for item := 1; item <= 3; item++ {
+		assert.Nil(t, list.Append(item))
+	}
+
+	item, err := list.Shift()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+	assert.Equal(t, []int{2, 3}, collect(list.Iterator()))
+	assert.Equal(t, []int{3, 2}, collect(list.ReverseIterator()))
+
+	for _, expected := range []int{2, 3} {
+		item, err = list.Shift()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, item)
+	}
+
+	length, err := list.Length()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, length)
+
+	assert.Nil(t, list.Append(4))
+	assert.Equal(t, []int{4}, collect(list.Iterator()))
+	assert.Equal(t, []int{4}, collect(list.ReverseIterator()))
+}
+
+func collect(iterator *ListIterator[int]) []int {
+	defer iterator.Close()
+
+	items := make([]int, 0)
+	for iterator.Next() {
+		items = append(items, iterator.Item())
+	}
+
+	return items
+}
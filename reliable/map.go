@@ -1,7 +1,6 @@
 package reliable
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"path"
@@ -15,6 +14,7 @@ type Map[Key types.Comparable, value any] struct {
 	storagePath string
 	rawMap      storage.RawMap
 	keys        List[Key]
+	codec       Codec
 }
 
 func (m Map[Key, Value]) Get(key Key) (Value, error) {
@@ -25,39 +25,44 @@ func (m Map[Key, Value]) Get(key Key) (Value, error) {
 	}
 
 	var value Value
-	err = json.Unmarshal(buf, &value)
+	err = m.codec.Unmarshal(buf, &value)
 	return value, err
 }
 
 func (m Map[Key, Value]) Set(key Key, value Value) error {
-	buf, err := json.Marshal(value)
+	buf, err := m.codec.Marshal(value)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	err = m.recordKey(key)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
+	return storage.RunInBatch(m.rawMap, func(batch storage.RawMap) error {
+		reliableMap := m.withRawMap(batch)
+		err := reliableMap.recordKey(key)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
 
-	return m.rawMap.Set(m.itemKeyPath(key), buf)
+		return batch.Set(m.itemKeyPath(key), buf)
+	})
 }
 
 func (m Map[Key, Value]) Delete(key Key) error {
-	err := m.rawMap.Delete(m.itemKeyPath(key))
-	if err != nil {
-		log.Println(err)
-		return err
-	}
+	return storage.RunInBatch(m.rawMap, func(batch storage.RawMap) error {
+		err := batch.Delete(m.itemKeyPath(key))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = m.withRawMap(batch).cleanUpKey(key)
+		if err != nil {
+			log.Println(err)
+		}
 
-	err = m.cleanUpKey(key)
-	if err != nil {
-		log.Println(err)
-	}
-
-	return err
+		return err
+	})
 }
 
 func (m Map[Key, Value]) Contain(key Key) (bool, error) {
@@ -68,6 +73,11 @@ func (m Map[Key, Value]) Keys() ([]Key, error) {
 	return m.keys.Items()
 }
 
+// KeyIterator walks through the keys in insertion order without loading all of them.
+func (m Map[Key, Value]) KeyIterator() *ListIterator[Key] {
+	return m.keys.Iterator()
+}
+
 func (m Map[Key, Value]) recordKey(key Key) error {
 	keyPath := m.keyRefPath(key)
 	contain, err := m.rawMap.Contain(keyPath)
@@ -86,7 +96,7 @@ func (m Map[Key, Value]) recordKey(key Key) error {
 		return err
 	}
 
-	buf, err := json.Marshal(nodeRef)
+	buf, err := m.codec.Marshal(nodeRef)
 	if err != nil {
 		log.Println(err)
 		return err
@@ -119,7 +129,7 @@ func (m Map[Key, Value]) cleanUpKey(key Key) error {
 	}
 
 	var nodeRef string
-	err = json.Unmarshal(buf, &nodeRef)
+	err = m.codec.Unmarshal(buf, &nodeRef)
 	if err != nil {
 		log.Println(err)
 		return err
@@ -128,9 +138,19 @@ func (m Map[Key, Value]) cleanUpKey(key Key) error {
 	err = m.keys.delete(nodeRef)
 	if err != nil {
 		log.Println(err)
+		return err
 	}
 
-	return err
+	return m.rawMap.Delete(keyPath)
+}
+
+func (m Map[Key, Value]) withRawMap(rawMap storage.RawMap) Map[Key, Value] {
+	return Map[Key, Value]{
+		storagePath: m.storagePath,
+		rawMap:      rawMap,
+		keys:        *m.keys.withRawMap(rawMap),
+		codec:       m.codec,
+	}
 }
 
 func (m Map[Key, Value]) keyRefPath(key Key) string {
@@ -144,8 +164,22 @@ func (m Map[Key, Value]) itemKeyPath(key Key) string {
 func NewMap[Key types.Comparable, Value any](
 	storagePath string,
 	refGen *idgen.IDGen,
-	rawMap storage.RawMap) (Map[Key, Value], error) {
-	keys, err := NewList[Key](path.Join(storagePath, "keys"), refGen, rawMap)
+	rawMap storage.RawMap,
+	format Format,
+) (Map[Key, Value], error) {
+	exist, err := rawMap.Contain(storagePath)
+	if err != nil {
+		log.Println(err)
+		return *new(Map[Key, Value]), err
+	}
+
+	codec, err := loadCodec(storagePath, rawMap, !exist, format)
+	if err != nil {
+		log.Println(err)
+		return *new(Map[Key, Value]), err
+	}
+
+	keys, err := NewList[Key](path.Join(storagePath, "keys"), refGen, rawMap, codec.Format())
 	if err != nil {
 		return *new(Map[Key, Value]), err
 	}
@@ -154,5 +188,6 @@ func NewMap[Key types.Comparable, Value any](
 		storagePath: storagePath,
 		rawMap:      rawMap,
 		keys:        keys,
+		codec:       codec,
 	}, nil
 }
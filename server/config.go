@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"tstore/database"
+	"tstore/storage"
+)
+
+type StorageEngine string
+
+const (
+	// FileStorageEngine stores each key in its own file under the data directory
+	FileStorageEngine StorageEngine = "file"
+	// PageStorageEngine stores all keys in a single page structured data file
+	PageStorageEngine StorageEngine = "page"
+	// LogStorageEngine appends all writes to log segments which are compacted in the background
+	LogStorageEngine StorageEngine = "log"
+)
+
+const (
+	pageFileName = "data.tsp"
+	logDirName   = "log"
+)
+
+type Config struct {
+	DataDir       string
+	StorageEngine StorageEngine
+	// FileDurability controls when the file storage engine flushes writes to disk
+	FileDurability storage.Durability
+	// EncryptionKeyFile is the master key file used to encrypt all the stored values, empty disables encryption
+	EncryptionKeyFile string
+	// CacheSize is the number of bytes of recently read values kept in memory, 0 disables the cache
+	CacheSize int64
+	Database  database.Config
+}
+
+func DefaultConfig() Config {
+	return Config{
+		DataDir:        "./userData",
+		StorageEngine:  FileStorageEngine,
+		FileDurability: storage.DurabilityFull,
+		CacheSize:      64 << 20,
+		Database:       database.DefaultConfig(),
+	}
+}
+
+func newRawMap(config Config) (storage.RawMap, error) {
+	rawMap, err := OpenStorageEngine(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.EncryptionKeyFile != "" {
+		keys, err := storage.LoadEncryptionKeys(config.EncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		rawMap, err = storage.NewEncryptedMap(rawMap, keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.CacheSize > 0 {
+		// values are cached after being decrypted
+		rawMap = storage.NewCachedMap(rawMap, config.CacheSize)
+	}
+
+	return rawMap, nil
+}
+
+// OpenStorageEngine opens the configured storage engine without any encryption applied.
+func OpenStorageEngine(config Config) (storage.RawMap, error) {
+	switch config.StorageEngine {
+	case FileStorageEngine, "":
+		return storage.NewFileMap(config.DataDir, config.FileDurability)
+	case PageStorageEngine:
+		return storage.NewPageMap(filepath.Join(config.DataDir, pageFileName))
+	case LogStorageEngine:
+		return storage.NewLogMap(filepath.Join(config.DataDir, logDirName), storage.DefaultLogMapOptions())
+	default:
+		return nil, fmt.Errorf("unknown storage engine: %v", config.StorageEngine)
+	}
+}
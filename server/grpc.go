@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net"
 
+	"tstore/data"
 	"tstore/proto"
+	"tstore/query/lang"
 
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -30,17 +32,46 @@ func (g GRPCServer) DeleteDatabase(ctx context.Context, request *proto.DeleteDat
 	return &emptypb.Empty{}, g.server.DeleteDatabase(request.Name)
 }
 
+func (g GRPCServer) ForkDatabase(ctx context.Context, request *proto.ForkDatabaseRequest) (*emptypb.Empty, error) {
+	commitRef := data.CommitRef{CommitID: request.TransactionId, Tag: request.Tag}
+	return &emptypb.Empty{}, g.server.ForkDatabase(request.SourceName, commitRef, request.Name)
+}
+
 func (g GRPCServer) CreateTransaction(ctx context.Context, request *proto.CreateTransactionRequest) (*emptypb.Empty, error) {
 	transactionInput, err := proto.FromProtoTransactionInput(request.Transaction)
 	if err != nil {
 		return nil, err
 	}
 
+	if request.Branch != "" {
+		return &emptypb.Empty{}, g.server.CreateBranchTransaction(request.DbName, request.Branch, transactionInput)
+	}
+
 	return &emptypb.Empty{}, g.server.CreateTransaction(request.DbName, transactionInput)
 }
 
 func (g GRPCServer) GetLatestCommit(ctx context.Context, request *proto.GetLatestCommitRequest) (*proto.Commit, error) {
-	commit, err := g.server.GetLatestCommit(request.DbName)
+	var commit data.Commit
+	var err error
+	if request.Branch != "" {
+		commit, err = g.server.GetBranchHead(request.DbName, request.Branch)
+	} else {
+		commit, err = g.server.GetLatestCommit(request.DbName)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoCommit(commit), nil
+}
+
+func (g GRPCServer) GetCommitAt(ctx context.Context, request *proto.GetCommitAtRequest) (*proto.Commit, error) {
+	if request.At == nil {
+		return nil, errors.New("at can't be nil")
+	}
+
+	commit, err := g.server.GetCommitAt(request.DbName, request.At.AsTime())
 	if err != nil {
 		return nil, err
 	}
@@ -48,13 +79,88 @@ func (g GRPCServer) GetLatestCommit(ctx context.Context, request *proto.GetLates
 	return proto.ToProtoCommit(commit), nil
 }
 
+func (g GRPCServer) ListCommits(request *proto.ListCommitsRequest, stream proto.Database_ListCommitsServer) error {
+	query := proto.FromProtoCommitLogQuery(request)
+	for {
+		page, err := g.server.ListCommits(request.DbName, query)
+		if err != nil {
+			return err
+		}
+
+		for _, commit := range page.Commits {
+			err = stream.Send(proto.ToProtoCommit(commit))
+			if err != nil {
+				return err
+			}
+		}
+
+		if request.PageSize > 0 || page.NextPageToken == 0 {
+			return nil
+		}
+
+		query.PageToken = page.NextPageToken
+	}
+}
+
 func (g GRPCServer) QueryEntitiesAtCommit(ctx context.Context, request *proto.QueryAtCommitRequest) (*proto.Entities, error) {
 	if request.Query == nil {
 		return nil, errors.New("query can't be nil")
 	}
 
+	commitRef := data.CommitRef{CommitID: request.TransactionId, Tag: request.Tag}
+	query := proto.FromProtoExpression(request.Query)
+	if request.Branch != "" {
+		entities, err := g.server.QueryBranchEntitiesAtCommit(request.DbName, request.Branch, commitRef, *query)
+		if err != nil {
+			return nil, err
+		}
+
+		return proto.ToProtoEntities(entities), nil
+	}
+
+	entities, err := g.server.QueryEntitiesAtCommit(request.DbName, commitRef, *query)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoEntities(entities), nil
+}
+
+func (g GRPCServer) QueryEntityGroupsAtCommit(ctx context.Context, request *proto.QueryAtCommitRequest) (*proto.Groups, error) {
+	if request.Query == nil {
+		return nil, errors.New("query can't be nil")
+	}
+
+	commitRef := data.CommitRef{CommitID: request.TransactionId, Tag: request.Tag}
+	query := proto.FromProtoExpression(request.Query)
+	if request.Branch != "" {
+		groups, err := g.server.QueryBranchEntityGroupsAtCommit(request.DbName, request.Branch, commitRef, *query)
+		if err != nil {
+			return nil, err
+		}
+
+		return proto.ToProtoGroups(groups), nil
+	}
+
+	groups, err := g.server.QueryEntityGroupsAtCommit(request.DbName, commitRef, *query)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoGroups(groups), nil
+}
+
+func (g GRPCServer) QueryEntitiesAsOf(ctx context.Context, request *proto.QueryAsOfRequest) (*proto.Entities, error) {
+	if request.At == nil {
+		return nil, errors.New("at can't be nil")
+	}
+
+	if request.Query == nil {
+		return nil, errors.New("query can't be nil")
+	}
+
 	query := proto.FromProtoExpression(request.Query)
-	entities, err := g.server.QueryEntitiesAtCommit(request.DbName, request.TransactionId, *query)
+	entities, err := g.server.QueryEntitiesAsOf(request.DbName, request.At.AsTime(), *query)
 	if err != nil {
 		return nil, err
 	}
@@ -62,29 +168,116 @@ func (g GRPCServer) QueryEntitiesAtCommit(ctx context.Context, request *proto.Qu
 	return proto.ToProtoEntities(entities), nil
 }
 
-func (g GRPCServer) QueryGroupsAtCommit(ctx context.Context, request *proto.QueryAtCommitRequest) (*proto.Groups, error) {
+func (g GRPCServer) QueryEntityGroupsAsOf(ctx context.Context, request *proto.QueryAsOfRequest) (*proto.Groups, error) {
+	if request.At == nil {
+		return nil, errors.New("at can't be nil")
+	}
+
 	if request.Query == nil {
 		return nil, errors.New("query can't be nil")
 	}
 
 	query := proto.FromProtoExpression(request.Query)
-	groups, err := g.server.QueryEntityGroupsAtCommit(request.DbName, request.TransactionId, *query)
+	groups, err := g.server.QueryEntityGroupsAsOf(request.DbName, request.At.AsTime(), *query)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoGroups(groups), nil
+}
+
+func (g GRPCServer) BlameEntity(ctx context.Context, request *proto.BlameEntityRequest) (*proto.EntityBlame, error) {
+	commitRef := data.CommitRef{CommitID: request.TransactionId, Tag: request.Tag}
+	blame, err := g.server.BlameEntity(request.DbName, commitRef, request.EntityId)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoEntityBlame(blame), nil
+}
+
+func (g GRPCServer) GetRetentionPolicy(
+	ctx context.Context,
+	request *proto.GetRetentionPolicyRequest,
+) (*proto.RetentionPolicy, error) {
+	policy, err := g.server.GetRetentionPolicy(request.DbName)
 	if err != nil {
 		return nil, err
 	}
 
-	protoGroups := make(map[string]*proto.Entities)
-	for key, entities := range groups {
-		protoGroups[key] = proto.ToProtoEntities(entities)
+	return proto.ToProtoRetentionPolicy(policy), nil
+}
+
+func (g GRPCServer) SetRetentionPolicy(ctx context.Context, request *proto.SetRetentionPolicyRequest) (*emptypb.Empty, error) {
+	policy := proto.FromProtoRetentionPolicy(request.Policy)
+	return &emptypb.Empty{}, g.server.SetRetentionPolicy(request.DbName, policy)
+}
+
+func (g GRPCServer) DiffCommits(ctx context.Context, request *proto.DiffCommitsRequest) (*proto.CommitDiff, error) {
+	filter := lang.Expression(lang.All)
+	if request.Filter != nil {
+		filter = *proto.FromProtoExpression(request.Filter)
+	}
+
+	fromCommitRef := data.CommitRef{CommitID: request.FromTransactionId, Tag: request.FromTag}
+	toCommitRef := data.CommitRef{CommitID: request.ToTransactionId, Tag: request.ToTag}
+	diff, err := g.server.DiffCommits(request.DbName, fromCommitRef, toCommitRef, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoCommitDiff(diff), nil
+}
+
+func (g GRPCServer) CreateTag(ctx context.Context, request *proto.CreateTagRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, g.server.CreateTag(request.DbName, request.Name, request.TransactionId)
+}
+
+func (g GRPCServer) ResolveTag(ctx context.Context, request *proto.ResolveTagRequest) (*proto.Tag, error) {
+	transactionID, err := g.server.ResolveTag(request.DbName, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoTag(data.Tag{Name: request.Name, CommitID: transactionID}), nil
+}
+
+func (g GRPCServer) ListTags(ctx context.Context, request *proto.ListTagsRequest) (*proto.Tags, error) {
+	tags, err := g.server.ListTags(request.DbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoTags(tags), nil
+}
+
+func (g GRPCServer) CreateBranch(ctx context.Context, request *proto.CreateBranchRequest) (*emptypb.Empty, error) {
+	commitRef := data.CommitRef{CommitID: request.TransactionId, Tag: request.Tag}
+	return &emptypb.Empty{}, g.server.CreateBranch(request.DbName, request.Name, commitRef)
+}
+
+func (g GRPCServer) ListBranches(ctx context.Context, request *proto.ListBranchesRequest) (*proto.Branches, error) {
+	branches, err := g.server.ListBranches(request.DbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.ToProtoBranches(branches), nil
+}
+
+func (g GRPCServer) MergeBranch(ctx context.Context, request *proto.MergeBranchRequest) (*proto.MergeResult, error) {
+	result, err := g.server.MergeBranch(request.DbName, request.Name, proto.FromProtoCommitMetadata(request.Metadata))
+	if err != nil {
+		return nil, err
 	}
 
-	return &proto.Groups{Groups: protoGroups}, nil
+	return proto.ToProtoMergeResult(result), nil
 }
 
 var _ proto.DatabaseServer = (*GRPCServer)(nil)
 
-func newGRPCServer() (*GRPCServer, error) {
-	server, err := newServer()
+func newGRPCServer(config Config) (*GRPCServer, error) {
+	server, err := newServer(config)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +287,13 @@ func newGRPCServer() (*GRPCServer, error) {
 	}, nil
 }
 
-func StartGRPCServer(port int) error {
+func StartGRPCServer(port int, config Config) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
 	}
 
-	grpcServer, err := newGRPCServer()
+	grpcServer, err := newGRPCServer(config)
 	if err != nil {
 		return err
 	}
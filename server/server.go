@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"time"
 
 	"tstore/data"
 	"tstore/database"
@@ -22,6 +23,7 @@ type Server struct {
 	dataStoragePath string
 	databasesMap    reliable.Map[string, bool]
 	databases       map[string]database.Database
+	dbConfig        database.Config
 }
 
 func (s Server) ListAllDatabases() ([]string, error) {
@@ -45,7 +47,7 @@ func (s Server) CreateDatabase(name string) error {
 	}
 
 	storagePath := path.Join(s.dataStoragePath, name)
-	db, err := database.NewDatabase(storagePath, s.refGen, s.rawMap)
+	db, err := database.NewDatabase(storagePath, s.refGen, s.rawMap, s.dbConfig)
 	if err != nil {
 		return err
 	}
@@ -54,6 +56,57 @@ func (s Server) CreateDatabase(name string) error {
 	return nil
 }
 
+// ForkDatabase creates a database which starts as a copy of the source database at the commit.
+func (s Server) ForkDatabase(sourceName string, commitRef data.CommitRef, name string) error {
+	source, ok := s.databases[sourceName]
+	if !ok {
+		return fmt.Errorf("database not found: name=%v", sourceName)
+	}
+
+	exist, err := s.databasesMap.Contain(name)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if exist {
+		return fmt.Errorf("database already exist: name=%v", name)
+	}
+
+	// clean up the data left by a fork interrupted before the database was recorded
+	storagePath := path.Join(s.dataStoragePath, name)
+	err = s.rawMap.Delete(storagePath)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	db, err := database.NewDatabase(storagePath, s.refGen, s.rawMap, s.dbConfig)
+	if err != nil {
+		return err
+	}
+
+	err = source.Fork(commitRef, db)
+	if err != nil {
+		log.Println(err)
+		deleteErr := db.DeleteAllData()
+		if deleteErr != nil {
+			log.Println(deleteErr)
+		}
+
+		return err
+	}
+
+	err = s.databasesMap.Set(name, true)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	s.databases[name] = db
+	return nil
+}
+
 func (s Server) DeleteDatabase(name string) error {
 	db, ok := s.databases[name]
 	if !ok {
@@ -79,18 +132,22 @@ func (s Server) CreateTransaction(dbName string, transactionInput mutation.Trans
 	return db.CreateTransaction(transactionInput)
 }
 
-func (s Server) QueryEntitiesAtCommit(dbName string, transactionID uint64, query lang.Expression) ([]data.Entity, error) {
+func (s Server) QueryEntitiesAtCommit(
+	dbName string,
+	commitRef data.CommitRef,
+	query lang.Expression,
+) ([]data.Entity, error) {
 	db, ok := s.databases[dbName]
 	if !ok {
 		return nil, fmt.Errorf("database not found: name=%v", dbName)
 	}
 
-	return db.QueryEntitiesAtCommit(transactionID, query)
+	return db.QueryEntitiesAtCommit(commitRef, query)
 }
 
 func (s Server) QueryEntityGroupsAtCommit(
 	dbName string,
-	transactionID uint64,
+	commitRef data.CommitRef,
 	query lang.Expression,
 ) (query.Groups[data.Entity], error) {
 	db, ok := s.databases[dbName]
@@ -98,20 +155,64 @@ func (s Server) QueryEntityGroupsAtCommit(
 		return nil, fmt.Errorf("database not found: name=%v", dbName)
 	}
 
-	return db.QueryEntityGroupsAtCommit(transactionID, query)
+	return db.QueryEntityGroupsAtCommit(commitRef, query)
+}
+
+func (s Server) QueryEntitiesAsOf(dbName string, at time.Time, query lang.Expression) ([]data.Entity, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.QueryEntitiesAsOf(at, query)
+}
+
+func (s Server) QueryEntityGroupsAsOf(
+	dbName string,
+	at time.Time,
+	query lang.Expression,
+) (query.Groups[data.Entity], error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.QueryEntityGroupsAsOf(at, query)
 }
 
 func (s Server) QueryEntitiesBetweenCommits(
 	dbName string,
-	beginCommitID uint64,
-	endCommitID uint64,
+	beginCommitRef data.CommitRef,
+	endCommitRef data.CommitRef,
 	query lang.Expression) (map[uint64][]history.Version[data.Entity], error) {
 	db, ok := s.databases[dbName]
 	if !ok {
 		return nil, fmt.Errorf("database not found: name=%v", dbName)
 	}
 
-	return db.QueryEntitiesBetweenCommits(beginCommitID, endCommitID, query)
+	return db.QueryEntitiesBetweenCommits(beginCommitRef, endCommitRef, query)
+}
+
+func (s Server) BlameEntity(dbName string, commitRef data.CommitRef, entityID uint64) (data.EntityBlame, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.EntityBlame{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.BlameEntity(commitRef, entityID)
+}
+
+func (s Server) DiffCommits(
+	dbName string,
+	fromCommitRef data.CommitRef,
+	toCommitRef data.CommitRef,
+	filter lang.Expression) (data.CommitDiff, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.CommitDiff{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.DiffCommits(fromCommitRef, toCommitRef, filter)
 }
 
 func (s Server) GetLatestCommit(dbName string) (data.Commit, error) {
@@ -123,8 +224,152 @@ func (s Server) GetLatestCommit(dbName string) (data.Commit, error) {
 	return db.GetLatestCommit()
 }
 
-func newServer() (Server, error) {
-	rawMap := storage.NewLocalFileMap("./userData")
+func (s Server) CreateTag(dbName string, name string, transactionID uint64) error {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.CreateTag(name, transactionID)
+}
+
+func (s Server) ResolveTag(dbName string, name string) (uint64, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return 0, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.ResolveTag(name)
+}
+
+func (s Server) ListTags(dbName string) ([]data.Tag, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.ListTags()
+}
+
+func (s Server) CreateBranch(dbName string, name string, commitRef data.CommitRef) error {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.CreateBranch(name, commitRef)
+}
+
+func (s Server) ListBranches(dbName string) ([]database.Branch, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.ListBranches()
+}
+
+func (s Server) CreateBranchTransaction(
+	dbName string,
+	branchName string,
+	transactionInput mutation.TransactionInput,
+) error {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.CreateBranchTransaction(branchName, transactionInput)
+}
+
+func (s Server) GetBranchHead(dbName string, branchName string) (data.Commit, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.Commit{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.GetBranchHead(branchName)
+}
+
+func (s Server) QueryBranchEntitiesAtCommit(
+	dbName string,
+	branchName string,
+	commitRef data.CommitRef,
+	query lang.Expression,
+) ([]data.Entity, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.QueryBranchEntitiesAtCommit(branchName, commitRef, query)
+}
+
+func (s Server) QueryBranchEntityGroupsAtCommit(
+	dbName string,
+	branchName string,
+	commitRef data.CommitRef,
+	query lang.Expression,
+) (query.Groups[data.Entity], error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.QueryBranchEntityGroupsAtCommit(branchName, commitRef, query)
+}
+
+func (s Server) MergeBranch(dbName string, branchName string, metadata data.CommitMetadata) (data.MergeResult, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.MergeResult{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.MergeBranch(branchName, metadata)
+}
+
+func (s Server) ListCommits(dbName string, query data.CommitLogQuery) (data.CommitLogPage, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.CommitLogPage{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.ListCommits(query)
+}
+
+func (s Server) GetCommitAt(dbName string, at time.Time) (data.Commit, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.Commit{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.CommitAt(at)
+}
+
+func (s Server) GetRetentionPolicy(dbName string) (data.RetentionPolicy, error) {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return data.RetentionPolicy{}, fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.RetentionPolicy()
+}
+
+func (s Server) SetRetentionPolicy(dbName string, policy data.RetentionPolicy) error {
+	db, ok := s.databases[dbName]
+	if !ok {
+		return fmt.Errorf("database not found: name=%v", dbName)
+	}
+
+	return db.SetRetentionPolicy(policy)
+}
+
+func newServer(config Config) (Server, error) {
+	rawMap, err := newRawMap(config)
+	if err != nil {
+		return Server{}, err
+	}
+
 	dbMap := make(map[string]database.Database)
 	refGen, err := idgen.New(path.Join("idGens", "refGen"), rawMap, 5)
 	if err != nil {
@@ -132,7 +377,7 @@ func newServer() (Server, error) {
 	}
 
 	databasesPath := path.Join("databases")
-	databasesMap, err := reliable.NewMap[string, bool](path.Join(databasesPath, "map"), refGen, rawMap)
+	databasesMap, err := reliable.NewMap[string, bool](path.Join(databasesPath, "map"), refGen, rawMap, config.Database.CollectionFormat)
 	if err != nil {
 		return Server{}, err
 	}
@@ -145,7 +390,7 @@ func newServer() (Server, error) {
 
 	dataStoragePath := path.Join(databasesPath, "data")
 	for _, dbName := range databaseNames {
-		db, err := database.NewDatabase(path.Join(dataStoragePath, dbName), refGen, rawMap)
+		db, err := database.NewDatabase(path.Join(dataStoragePath, dbName), refGen, rawMap, config.Database)
 		if err != nil {
 			return Server{}, err
 		}
@@ -159,5 +404,6 @@ func newServer() (Server, error) {
 		dataStoragePath: path.Join(databasesPath, "data"),
 		databasesMap:    databasesMap,
 		databases:       dbMap,
+		dbConfig:        config.Database,
 	}, nil
 }
@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// Batch stages Set & Delete operations and applies all of them atomically on Commit.
+// Reads on a batch observe the staged operations.
+type Batch interface {
+	RawMap
+	Batcher
+	Commit() error
+	Abort() error
+}
+
+type Batcher interface {
+	Begin() (Batch, error)
+}
+
+func Begin(rawMap RawMap) (Batch, error) {
+	batcher, ok := rawMap.(Batcher)
+	if !ok {
+		return nil, fmt.Errorf("batch not supported: %T", rawMap)
+	}
+
+	return batcher.Begin()
+}
+
+// RunInBatch stages every write of the operation in a single batch,
+// so that the operation is either fully persisted or not persisted at all.
+func RunInBatch(rawMap RawMap, operation func(batch RawMap) error) error {
+	batch, err := Begin(rawMap)
+	if err != nil {
+		return err
+	}
+
+	err = operation(batch)
+	if err != nil {
+		abortErr := batch.Abort()
+		if abortErr != nil {
+			log.Println(abortErr)
+		}
+
+		return err
+	}
+
+	return batch.Commit()
+}
+
+type batchOp struct {
+	key     string
+	data    []byte
+	deleted bool
+}
+
+type stagedBatch struct {
+	mut      sync.RWMutex
+	base     RawMap
+	ops      []batchOp
+	apply    func(ops []batchOp) error
+	finished bool
+}
+
+var _ Batch = (*stagedBatch)(nil)
+
+func (s *stagedBatch) Get(key string) ([]byte, error) {
+	s.mut.RLock()
+	for index := len(s.ops) - 1; index >= 0; index-- {
+		op := s.ops[index]
+		if op.deleted && isSameOrNested(key, op.key) {
+			s.mut.RUnlock()
+			return nil, KeyNotFound(key)
+		}
+
+		if !op.deleted && op.key == key {
+			s.mut.RUnlock()
+			return op.data, nil
+		}
+	}
+	s.mut.RUnlock()
+
+	return s.base.Get(key)
+}
+
+func (s *stagedBatch) Set(key string, data []byte) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.finished {
+		return errBatchFinished
+	}
+
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	s.ops = append(s.ops, batchOp{key: key, data: buf})
+	return nil
+}
+
+func (s *stagedBatch) Contain(key string) (bool, error) {
+	s.mut.RLock()
+	for index := len(s.ops) - 1; index >= 0; index-- {
+		op := s.ops[index]
+		if op.deleted && isSameOrNested(key, op.key) {
+			s.mut.RUnlock()
+			return false, nil
+		}
+
+		if !op.deleted && isSameOrNested(op.key, key) {
+			s.mut.RUnlock()
+			return true, nil
+		}
+	}
+	s.mut.RUnlock()
+
+	return s.base.Contain(key)
+}
+
+func (s *stagedBatch) Delete(key string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.finished {
+		return errBatchFinished
+	}
+
+	s.ops = append(s.ops, batchOp{key: key, deleted: true})
+	return nil
+}
+
+func (s *stagedBatch) Scan(prefix string) (Iterator, error) {
+	return s.ScanRange(prefix, prefixEnd(prefix))
+}
+
+// ScanRange merges the staged operations into the keys of the underlying map.
+func (s *stagedBatch) ScanRange(start string, end string) (Iterator, error) {
+	iterator, err := ScanRange(s.base, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	keys := make(map[string]bool)
+	for iterator.Next() {
+		keys[iterator.Key()] = true
+	}
+
+	if iterator.Err() != nil {
+		return nil, iterator.Err()
+	}
+
+	s.mut.RLock()
+	for _, op := range s.ops {
+		if !op.deleted {
+			if inRange(op.key, start, end) {
+				keys[op.key] = true
+			}
+
+			continue
+		}
+
+		for key := range keys {
+			if isSameOrNested(key, op.key) {
+				delete(keys, key)
+			}
+		}
+	}
+	s.mut.RUnlock()
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+
+	return newSnapshotIterator(sortedKeys, func(key string) ([]byte, bool, error) {
+		contain, err := s.Contain(key)
+		if err != nil || !contain {
+			return nil, false, err
+		}
+
+		buf, err := s.Get(key)
+		return buf, err == nil, err
+	}), nil
+}
+
+// Begin starts a nested batch which is folded into this batch on Commit.
+func (s *stagedBatch) Begin() (Batch, error) {
+	return newStagedBatch(s, func(ops []batchOp) error {
+		s.mut.Lock()
+		defer s.mut.Unlock()
+
+		if s.finished {
+			return errBatchFinished
+		}
+
+		s.ops = append(s.ops, ops...)
+		return nil
+	}), nil
+}
+
+func (s *stagedBatch) Commit() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.finished {
+		return errBatchFinished
+	}
+
+	s.finished = true
+	if len(s.ops) == 0 {
+		return nil
+	}
+
+	return s.apply(s.ops)
+}
+
+func (s *stagedBatch) Abort() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.finished {
+		return errBatchFinished
+	}
+
+	s.finished = true
+	s.ops = nil
+	return nil
+}
+
+var errBatchFinished = errors.New("batch already committed or aborted")
+
+// isSameOrNested returns whether key equals to dir or is nested under dir
+func isSameOrNested(key string, dir string) bool {
+	return key == dir || strings.HasPrefix(key, dir+"/")
+}
+
+func newStagedBatch(base RawMap, apply func(ops []batchOp) error) *stagedBatch {
+	return &stagedBatch{
+		base:  base,
+		ops:   make([]batchOp, 0),
+		apply: apply,
+	}
+}
@@ -0,0 +1,350 @@
+package storage
+
+import (
+	"container/list"
+	"path"
+	"strings"
+	"sync"
+)
+
+// cacheEntryOverhead approximates the memory used by an entry besides its key & value
+const cacheEntryOverhead = 64
+
+type cacheKey struct {
+	key string
+	// contain marks the cached result of Contain instead of Get
+	contain bool
+}
+
+type cacheEntry struct {
+	cacheKey
+	value []byte
+	// exist is the cached result of Contain
+	exist bool
+	size  int64
+}
+
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Size    int64
+	Entries int
+}
+
+// CachedMap keeps the recently read values of the underlying RawMap in a LRU cache bounded by bytes.
+//
+// Writes go through to the underlying RawMap before the cache is updated. Contain results are cached
+// as well: a Set makes its ancestors contain the key and a Delete invalidates the key, the keys nested
+// under it and its ancestors.
+type CachedMap struct {
+	mut      sync.Mutex
+	rawMap   RawMap
+	capacity int64
+	size     int64
+	entries  map[cacheKey]*list.Element
+	// children indexes the cached keys by their parent key, so the keys nested under a deleted key
+	// are found without going through the whole cache
+	children map[string]map[string]bool
+	lru      *list.List
+	// version changes before and after every write, so a value read during a write is never cached
+	version uint64
+	hits    uint64
+	misses  uint64
+}
+
+var _ RawMap = (*CachedMap)(nil)
+
+func (c *CachedMap) Get(key string) ([]byte, error) {
+	c.mut.Lock()
+	entry, ok := c.lookup(cacheKey{key: key})
+	if ok {
+		c.mut.Unlock()
+		return copyBytes(entry.value), nil
+	}
+
+	version := c.version
+	c.mut.Unlock()
+
+	value, err := c.rawMap.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.version == version {
+		c.store(&cacheEntry{cacheKey: cacheKey{key: key}, value: copyBytes(value)})
+	}
+
+	return value, nil
+}
+
+func (c *CachedMap) Set(key string, data []byte) error {
+	c.beginWrite()
+	err := c.rawMap.Set(key, data)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.version++
+	if err != nil {
+		c.invalidate(key)
+		return err
+	}
+
+	c.set(key, data)
+	return nil
+}
+
+func (c *CachedMap) Contain(key string) (bool, error) {
+	c.mut.Lock()
+	entry, ok := c.lookup(cacheKey{key: key, contain: true})
+	if ok {
+		c.mut.Unlock()
+		return entry.exist, nil
+	}
+
+	version := c.version
+	c.mut.Unlock()
+
+	contain, err := c.rawMap.Contain(key)
+	if err != nil {
+		return false, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.version == version {
+		c.store(&cacheEntry{cacheKey: cacheKey{key: key, contain: true}, exist: contain})
+	}
+
+	return contain, nil
+}
+
+func (c *CachedMap) Delete(key string) error {
+	c.beginWrite()
+	err := c.rawMap.Delete(key)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.version++
+	c.invalidate(key)
+	return err
+}
+
+func (c *CachedMap) Scan(prefix string) (Iterator, error) {
+	return Scan(c.rawMap, prefix)
+}
+
+func (c *CachedMap) ScanRange(start string, end string) (Iterator, error) {
+	return ScanRange(c.rawMap, start, end)
+}
+
+// Begin starts a batch whose reads are served from the cache.
+func (c *CachedMap) Begin() (Batch, error) {
+	return newStagedBatch(c, c.applyBatch), nil
+}
+
+func (c *CachedMap) Stats() CacheStats {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return CacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Size:    c.size,
+		Entries: len(c.entries),
+	}
+}
+
+func (c *CachedMap) applyBatch(ops []batchOp) error {
+	batch, err := Begin(c.rawMap)
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if op.deleted {
+			err = batch.Delete(op.key)
+		} else {
+			err = batch.Set(op.key, op.data)
+		}
+
+		if err != nil {
+			batch.Abort()
+			return err
+		}
+	}
+
+	c.beginWrite()
+	err = batch.Commit()
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.version++
+	for _, op := range ops {
+		if op.deleted || err != nil {
+			c.invalidate(op.key)
+		} else {
+			c.set(op.key, op.data)
+		}
+	}
+
+	return err
+}
+
+func (c *CachedMap) beginWrite() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.version++
+}
+
+func (c *CachedMap) lookup(key cacheKey) (*cacheEntry, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry), true
+}
+
+func (c *CachedMap) set(key string, data []byte) {
+	c.store(&cacheEntry{cacheKey: cacheKey{key: key}, value: copyBytes(data)})
+	for dir := key; dir != "." && dir != "/" && dir != ""; dir = path.Dir(dir) {
+		element, ok := c.entries[cacheKey{key: dir, contain: true}]
+		if ok {
+			element.Value.(*cacheEntry).exist = true
+		}
+	}
+}
+
+func (c *CachedMap) store(entry *cacheEntry) {
+	entry.size = int64(len(entry.key)+len(entry.value)) + cacheEntryOverhead
+	c.remove(entry.cacheKey)
+	if entry.size > c.capacity {
+		return
+	}
+
+	c.entries[entry.cacheKey] = c.lru.PushFront(entry)
+	c.size += entry.size
+	c.link(entry.key)
+	for c.size > c.capacity {
+		c.remove(c.lru.Back().Value.(*cacheEntry).cacheKey)
+	}
+}
+
+// invalidate drops the entries which may change after the key is deleted
+func (c *CachedMap) invalidate(key string) {
+	c.removeNested(key)
+	for dir := path.Dir(key); dir != "." && dir != "/" && dir != ""; dir = path.Dir(dir) {
+		c.remove(cacheKey{key: dir, contain: true})
+	}
+}
+
+func (c *CachedMap) remove(key cacheKey) {
+	element, ok := c.entries[key]
+	if !ok {
+		return
+	}
+
+	c.lru.Remove(element)
+	delete(c.entries, key)
+	c.size -= element.Value.(*cacheEntry).size
+	c.unlink(key.key)
+}
+
+// removeNested removes the entries of the key and of the keys nested under it
+func (c *CachedMap) removeNested(key string) {
+	for child := range c.children[key] {
+		c.removeNested(child)
+	}
+
+	c.remove(cacheKey{key: key})
+	c.remove(cacheKey{key: key, contain: true})
+}
+
+// link adds the key to the children of its ancestors
+func (c *CachedMap) link(key string) {
+	for parent, ok := parentKey(key); ok; parent, ok = parentKey(key) {
+		children, exist := c.children[parent]
+		if !exist {
+			children = make(map[string]bool)
+			c.children[parent] = children
+		}
+
+		if children[key] {
+			return
+		}
+
+		children[key] = true
+		key = parent
+	}
+}
+
+// unlink removes the key from the children of its parent once nothing is cached under it,
+// the ancestors left empty are removed as well
+func (c *CachedMap) unlink(key string) {
+	for parent, ok := parentKey(key); ok; parent, ok = parentKey(key) {
+		if c.isIndexed(key) {
+			return
+		}
+
+		children := c.children[parent]
+		delete(children, key)
+		if len(children) > 0 {
+			return
+		}
+
+		delete(c.children, parent)
+		key = parent
+	}
+}
+
+func (c *CachedMap) isIndexed(key string) bool {
+	if len(c.children[key]) > 0 {
+		return true
+	}
+
+	_, ok := c.entries[cacheKey{key: key}]
+	if ok {
+		return true
+	}
+
+	_, ok = c.entries[cacheKey{key: key, contain: true}]
+	return ok
+}
+
+// parentKey returns the key the key is nested under, false for a top level key
+func parentKey(key string) (string, bool) {
+	index := strings.LastIndex(key, "/")
+	if index <= 0 {
+		return "", false
+	}
+
+	return key[:index], true
+}
+
+func copyBytes(data []byte) []byte {
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	return buf
+}
+
+// NewCachedMap caches up to capacity bytes of keys & values read from rawMap.
+func NewCachedMap(rawMap RawMap, capacity int64) *CachedMap {
+	return &CachedMap{
+		rawMap:   rawMap,
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		children: make(map[string]map[string]bool),
+		lru:      list.New(),
+	}
+}
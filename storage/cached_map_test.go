@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingMap counts the reads reaching the underlying RawMap
+type countingMap struct {
+	InMemoryMap
+	mut   sync.Mutex
+	reads int
+}
+
+func (c *countingMap) Get(key string) ([]byte, error) {
+	c.count()
+	return c.InMemoryMap.Get(key)
+}
+
+func (c *countingMap) Contain(key string) (bool, error) {
+	c.count()
+	return c.InMemoryMap.Contain(key)
+}
+
+func (c *countingMap) count() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.reads++
+}
+
+func TestCachedMap(t *testing.T) {
+	rawMap := &countingMap{InMemoryMap: NewInMemoryMap()}
+	cachedMap := NewCachedMap(rawMap, 1024)
+	assert.Nil(t, cachedMap.Set("list/tail", []byte("\"list/nodes/1\"")))
+	assert.Nil(t, cachedMap.Set("list/nodes/1/data", []byte("Harry")))
+
+	contain, err := cachedMap.Contain("list/nodes/2")
+	assert.Nil(t, err)
+	assert.False(t, contain)
+
+	// warm reads never reach the underlying map
+	reads := rawMap.reads
+	for index := 0; index < 10; index++ {
+		buf, err := cachedMap.Get("list/tail")
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("\"list/nodes/1\""), buf)
+
+		contain, err = cachedMap.Contain("list/nodes/2")
+		assert.Nil(t, err)
+		assert.False(t, contain)
+	}
+
+	assert.Equal(t, reads, rawMap.reads)
+	assert.Equal(t, uint64(20), cachedMap.Stats().Hits)
+
+	// a nested key makes its ancestors exist
+	assert.Nil(t, cachedMap.Set("list/nodes/2/data", []byte("Ron")))
+	contain, err = cachedMap.Contain("list/nodes/2")
+	assert.Nil(t, err)
+	assert.True(t, contain)
+
+	assert.Nil(t, cachedMap.Delete("list/nodes"))
+	for _, key := range []string{"list/nodes/1/data", "list/nodes/2", "list/nodes"} {
+		contain, err = cachedMap.Contain(key)
+		assert.Nil(t, err)
+		assert.False(t, contain, key)
+	}
+
+	batch, err := cachedMap.Begin()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Set("list/tail", []byte("\"list/nodes/3\"")))
+	assert.Nil(t, batch.Commit())
+
+	buf, err := cachedMap.Get("list/tail")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\"list/nodes/3\""), buf)
+
+	// changing a value read from the cache leaves the cache intact
+	buf[0] = 'x'
+	buf, err = cachedMap.Get("list/tail")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\"list/nodes/3\""), buf)
+
+	// deleting a key drops the index of the keys nested under it
+	assert.Nil(t, cachedMap.Delete("list"))
+	assert.Empty(t, cachedMap.children)
+
+	// the cache never grows beyond its capacity
+	for index := 0; index < 100; index++ {
+		assert.Nil(t, cachedMap.Set(fmt.Sprintf("keys/%v", index), make([]byte, 100)))
+	}
+
+	stats := cachedMap.Stats()
+	assert.True(t, stats.Size <= 1024)
+	assert.True(t, stats.Entries < 10)
+
+	buf, err = cachedMap.Get("keys/0")
+	assert.Nil(t, err)
+	assert.Equal(t, make([]byte, 100), buf)
+}
+
+func TestCachedMap_Concurrent(t *testing.T) {
+	cachedMap := NewCachedMap(NewInMemoryMap(), 1<<20)
+	var group sync.WaitGroup
+	for writer := 0; writer < 4; writer++ {
+		// the readers never see a missing key
+		assert.Nil(t, cachedMap.Set(fmt.Sprintf("keys/%v", writer), []byte("0")))
+		group.Add(2)
+		go func(writer int) {
+			defer group.Done()
+			for index := 0; index < 200; index++ {
+				assert.Nil(t, cachedMap.Set(fmt.Sprintf("keys/%v", writer), []byte(fmt.Sprintf("%v", index))))
+			}
+		}(writer)
+
+		go func(writer int) {
+			defer group.Done()
+			for index := 0; index < 200; index++ {
+				_, err := cachedMap.Get(fmt.Sprintf("keys/%v", writer))
+				assert.Nil(t, err)
+			}
+		}(writer)
+	}
+
+	group.Wait()
+	for writer := 0; writer < 4; writer++ {
+		buf, err := cachedMap.Get(fmt.Sprintf("keys/%v", writer))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("199"), buf)
+	}
+}
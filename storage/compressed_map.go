@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type Compression byte
+
+const (
+	NoCompression      Compression = 0
+	SnappyCompression  Compression = 1
+	ZstdCompression    Compression = 2
+	DeflateCompression Compression = 3
+)
+
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(buf []byte) ([]byte, error)
+}
+
+// compressedValueMagic starts with a zero byte which never begins the JSON values written without compression
+var compressedValueMagic = []byte{0x00, 'Z'}
+
+const compressedValueHeaderSize = 3 // magic(2) compression(1)
+
+var (
+	compressorsMut sync.RWMutex
+	compressors    = map[Compression]Compressor{
+		NoCompression:      noCompressor{},
+		SnappyCompression:  snappyCompressor{},
+		ZstdCompression:    newZstdCompressor(),
+		DeflateCompression: deflateCompressor{},
+	}
+	compressionNames = map[Compression]string{
+		NoCompression:      "none",
+		SnappyCompression:  "snappy",
+		ZstdCompression:    "zstd",
+		DeflateCompression: "deflate",
+	}
+)
+
+// RegisterCompressor adds or replaces the implementation of a compression.
+func RegisterCompressor(compression Compression, name string, compressor Compressor) {
+	compressorsMut.Lock()
+	defer compressorsMut.Unlock()
+
+	compressors[compression] = compressor
+	compressionNames[compression] = name
+}
+
+func ParseCompression(name string) (Compression, error) {
+	compressorsMut.RLock()
+	defer compressorsMut.RUnlock()
+
+	for compression, currName := range compressionNames {
+		if currName == name {
+			return compression, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown compression: %v", name)
+}
+
+func (c Compression) String() string {
+	compressorsMut.RLock()
+	defer compressorsMut.RUnlock()
+
+	name, ok := compressionNames[c]
+	if !ok {
+		return fmt.Sprintf("compression(%d)", byte(c))
+	}
+
+	return name
+}
+
+func getCompressor(compression Compression) (Compressor, error) {
+	compressorsMut.RLock()
+	defer compressorsMut.RUnlock()
+
+	compressor, ok := compressors[compression]
+	if !ok {
+		return nil, fmt.Errorf("compressor not registered: %v", compression)
+	}
+
+	return compressor, nil
+}
+
+// CompressedMap compresses every value written to the underlying RawMap.
+//
+// Each value starts with a header recording its compression, so values written with different
+// compressions can be mixed and the compression can be changed without rewriting existing data.
+// Values without the header were written before compression was enabled and are read as is.
+type CompressedMap struct {
+	transformedMap
+	compression Compression
+}
+
+var _ RawMap = (*CompressedMap)(nil)
+
+func (c CompressedMap) compress(key string, data []byte) ([]byte, error) {
+	compressor, err := getCompressor(c.compression)
+	if err != nil {
+		return nil, err
+	}
+
+	compression := c.compression
+	compressed, err := compressor.Compress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(compressed) >= len(data) {
+		// not worth decompressing
+		compression = NoCompression
+		compressed = data
+	}
+
+	buf := make([]byte, compressedValueHeaderSize, compressedValueHeaderSize+len(compressed))
+	copy(buf, compressedValueMagic)
+	buf[len(compressedValueMagic)] = byte(compression)
+	return append(buf, compressed...), nil
+}
+
+func (c CompressedMap) decompress(key string, buf []byte) ([]byte, error) {
+	if len(buf) < compressedValueHeaderSize || !bytes.Equal(buf[:len(compressedValueMagic)], compressedValueMagic) {
+		return buf, nil
+	}
+
+	compressor, err := getCompressor(Compression(buf[len(compressedValueMagic)]))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := compressor.Decompress(buf[compressedValueHeaderSize:])
+	if err != nil {
+		return nil, fmt.Errorf("fail to decompress %v: %w", key, err)
+	}
+
+	return data, nil
+}
+
+func NewCompressedMap(rawMap RawMap, compression Compression) (*CompressedMap, error) {
+	_, err := getCompressor(compression)
+	if err != nil {
+		return nil, err
+	}
+
+	compressedMap := &CompressedMap{compression: compression}
+	compressedMap.transformedMap = transformedMap{
+		rawMap: rawMap,
+		encode: compressedMap.compress,
+		decode: compressedMap.decompress,
+	}
+	return compressedMap, nil
+}
+
+type noCompressor struct {
+}
+
+func (n noCompressor) Compress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func (n noCompressor) Decompress(buf []byte) ([]byte, error) {
+	return buf, nil
+}
+
+// zstdCompressor compresses better than snappy at a higher cost, the encoder & the decoder are shared by all values.
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCompressor() zstdCompressor {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		// only invalid options fail
+		panic(err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return zstdCompressor{
+		encoder: encoder,
+		decoder: decoder,
+	}
+}
+
+func (z zstdCompressor) Compress(data []byte) ([]byte, error) {
+	return z.encoder.EncodeAll(data, nil), nil
+}
+
+func (z zstdCompressor) Decompress(buf []byte) ([]byte, error) {
+	return z.decoder.DecodeAll(buf, nil)
+}
+
+type deflateCompressor struct {
+}
+
+func (d deflateCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	return buf.Bytes(), err
+}
+
+func (d deflateCompressor) Decompress(buf []byte) ([]byte, error) {
+	reader := flate.NewReader(bytes.NewReader(buf))
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
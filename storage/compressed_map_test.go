@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressedMap(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomValue := make([]byte, 10000)
+	random.Read(randomValue)
+
+	values := [][]byte{
+		{},
+		[]byte("\"Harry\""),
+		bytes.Repeat([]byte("{\"name\":\"Harry\",\"house\":\"Gryffindor\"}"), 200),
+		randomValue,
+	}
+
+	rawMap := NewInMemoryMap()
+	for _, compression := range []Compression{NoCompression, SnappyCompression, ZstdCompression, DeflateCompression} {
+		compressedMap, err := NewCompressedMap(rawMap, compression)
+		assert.Nil(t, err)
+
+		for index, value := range values {
+			key := compression.String() + "/" + string(rune('a'+index))
+			assert.Nil(t, compressedMap.Set(key, value))
+
+			buf, err := compressedMap.Get(key)
+			assert.Nil(t, err)
+			assert.Equal(t, value, buf)
+		}
+	}
+
+	for _, key := range []string{"snappy/c", "zstd/c"} {
+		stored, err := rawMap.Get(key)
+		assert.Nil(t, err)
+		assert.True(t, len(stored) < len(values[2])/10, key)
+	}
+
+	// values written with another compression or without compression stay readable
+	assert.Nil(t, rawMap.Set("legacy", []byte("\"Potter\"")))
+	compressedMap, err := NewCompressedMap(rawMap, DeflateCompression)
+	assert.Nil(t, err)
+	for _, key := range []string{"snappy/c", "zstd/c", "none/c", "legacy"} {
+		buf, err := compressedMap.Get(key)
+		assert.Nil(t, err)
+
+		expected := values[2]
+		if key == "legacy" {
+			expected = []byte("\"Potter\"")
+		}
+
+		assert.Equal(t, expected, buf)
+	}
+
+	_, err = NewCompressedMap(rawMap, Compression(4))
+	assert.NotNil(t, err)
+}
+
+func TestSnappyCompressor(t *testing.T) {
+	compressor := snappyCompressor{}
+	random := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		// mix random bytes with repeated runs to produce every tag type
+		value := make([]byte, 0)
+		size := random.Intn(100000)
+		for len(value) < size {
+			if random.Intn(2) == 0 || len(value) == 0 {
+				chunk := make([]byte, random.Intn(300))
+				random.Read(chunk)
+				value = append(value, chunk...)
+				continue
+			}
+
+			start := random.Intn(len(value))
+			end := start + random.Intn(len(value)-start)
+			value = append(value, value[start:end]...)
+		}
+
+		buf, err := compressor.Compress(value)
+		assert.Nil(t, err)
+
+		decompressed, err := compressor.Decompress(buf)
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(value, decompressed))
+	}
+
+	_, err := compressor.Decompress([]byte{10, 0x02, 0x01, 0x00})
+	assert.Equal(t, errCorruptedSnappy, err)
+}
+
+// snappyVectors are blocks assembled by hand from the snappy format description, covering every tag
+var snappyVectors = []struct {
+	name    string
+	encoded string
+	decoded string
+}{
+	{name: "Empty", encoded: "\x00", decoded: ""},
+	{name: "Literal", encoded: "\x03\x08\xff\xfe\xfd", decoded: "\xff\xfe\xfd"},
+	{name: "Literal1ByteLength", encoded: "\x03\xf0\x02\xff\xfe\xfd", decoded: "\xff\xfe\xfd"},
+	{name: "Literal2ByteLength", encoded: "\x03\xf4\x02\x00\xff\xfe\xfd", decoded: "\xff\xfe\xfd"},
+	{name: "Literal3ByteLength", encoded: "\x03\xf8\x02\x00\x00\xff\xfe\xfd", decoded: "\xff\xfe\xfd"},
+	{name: "Literal4ByteLength", encoded: "\x03\xfc\x02\x00\x00\x00\xff\xfe\xfd", decoded: "\xff\xfe\xfd"},
+	{name: "Copy1", encoded: "\x08\x0cabcd\x01\x04", decoded: "abcdabcd"},
+	{name: "Copy2", encoded: "\x08\x0cabcd\x0e\x04\x00", decoded: "abcdabcd"},
+	{name: "Copy4", encoded: "\x08\x0cabcd\x0f\x04\x00\x00\x00", decoded: "abcdabcd"},
+	{name: "OverlappingCopy", encoded: "\x0a\x00a\x15\x01", decoded: "aaaaaaaaaa"},
+	{name: "Copy1HighOffset", encoded: "\x8a\x02\xf4\x01\x01" + strings.Repeat("x", 256) + "yz\x31\x02", decoded: strings.Repeat("x", 256) + "yz" + strings.Repeat("x", 8)},
+}
+
+func TestSnappyCompressor_Vectors(t *testing.T) {
+	compressor := snappyCompressor{}
+	for _, vector := range snappyVectors {
+		t.Run(vector.name, func(t *testing.T) {
+			decoded, err := compressor.Decompress([]byte(vector.encoded))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte(vector.decoded), decoded)
+		})
+	}
+
+	// short values are stored as a single literal, like the reference encoder does
+	for _, vector := range snappyVectors[:2] {
+		encoded, err := compressor.Compress([]byte(vector.decoded))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(vector.encoded), encoded)
+	}
+
+	corruptedBlocks := []string{
+		"",
+		"\xff",
+		"\x02\x08\xff\xfe\xfd",
+		"\x04\x08\xff\xfe\xfd",
+		"\x03\x08\xff\xfe",
+		"\x03\xf0",
+		"\x08\x0cabcd\x01\x00",
+		"\x08\x0cabcd\x01\x05",
+		"\x08\x0cabcd\x0e\x04",
+		"\x08\x0cabcd\x0f\x04\x00\x00",
+		"\x06\x0cabcd\x01\x04",
+		// a length close to 4GB is rejected without allocating it
+		"\xff\xff\xff\xff\x0f\x00a",
+	}
+	for _, block := range corruptedBlocks {
+		_, err := compressor.Decompress([]byte(block))
+		assert.Equal(t, errCorruptedSnappy, err, "%q", block)
+	}
+}
+
+func FuzzSnappyDecompress(f *testing.F) {
+	for _, vector := range snappyVectors {
+		f.Add([]byte(vector.encoded))
+	}
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		decoded, err := snappyCompressor{}.Decompress(buf)
+		if err != nil {
+			assert.Equal(t, errCorruptedSnappy, err)
+			return
+		}
+
+		length, _ := binary.Uvarint(buf)
+		assert.Equal(t, length, uint64(len(decoded)))
+	})
+}
+
+func FuzzSnappyCompress(f *testing.F) {
+	for _, vector := range snappyVectors {
+		f.Add([]byte(vector.decoded))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		compressor := snappyCompressor{}
+		buf, err := compressor.Compress(data)
+		assert.Nil(t, err)
+
+		decompressed, err := compressor.Decompress(buf)
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(data, decompressed))
+	})
+}
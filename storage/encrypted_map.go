@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// encryptedValueMagic starts with a zero byte which never begins the values written without encryption
+var encryptedValueMagic = []byte{0x00, 'E'}
+
+const (
+	encryptionKeyIDSize = 4
+	encryptionNonceSize = 12
+	// encryptedValueHeaderSize is magic(2) key ID(4) nonce(12)
+	encryptedValueHeaderSize = 18
+	minMasterKeySize         = 16
+)
+
+var dataKeyInfo = []byte("tstore value encryption")
+
+// EncryptionKeys is the content of a master key file.
+// Values are always encrypted with the active key, the other keys are kept to read values encrypted before a rotation.
+type EncryptionKeys struct {
+	ActiveKeyID uint32            `json:"activeKeyID"`
+	Keys        map[uint32][]byte `json:"keys"`
+}
+
+// Rotate generates a new master key and makes it the active key.
+func (e *EncryptionKeys) Rotate() (uint32, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if e.Keys == nil {
+		e.Keys = make(map[uint32][]byte)
+	}
+
+	var keyID uint32
+	for currKeyID := range e.Keys {
+		if currKeyID > keyID {
+			keyID = currKeyID
+		}
+	}
+
+	keyID++
+	e.Keys[keyID] = key
+	e.ActiveKeyID = keyID
+	return keyID, nil
+}
+
+// Save atomically replaces the master key file.
+func (e EncryptionKeys) Save(filePath string) error {
+	buf, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filePath + tmpExt
+	err = ioutil.WriteFile(tmpPath, buf, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpPath, filePath)
+	if err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(filePath))
+}
+
+func LoadEncryptionKeys(filePath string) (EncryptionKeys, error) {
+	buf, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return EncryptionKeys{}, err
+	}
+
+	var keys EncryptionKeys
+	err = json.Unmarshal(buf, &keys)
+	if err != nil {
+		return EncryptionKeys{}, err
+	}
+
+	if _, ok := keys.Keys[keys.ActiveKeyID]; !ok {
+		return EncryptionKeys{}, fmt.Errorf("active encryption key not found: keyID=%v", keys.ActiveKeyID)
+	}
+
+	return keys, nil
+}
+
+// EncryptedMap encrypts every value written to the underlying RawMap with AES-GCM.
+//
+// The data keys are derived from the master keys with HKDF-SHA256. Each value starts with a header
+// holding the ID of the master key & the nonce, and its storage key is authenticated together with
+// it, so a value copied to another key fails to decrypt. Values without the header are rejected,
+// the values written before encryption was enabled have to be encrypted by Rekey first.
+type EncryptedMap struct {
+	transformedMap
+	activeKeyID uint32
+	aeads       map[uint32]cipher.AEAD
+	// readPlaintext reads the values without the header as is, only while migrating them
+	readPlaintext bool
+}
+
+var _ RawMap = (*EncryptedMap)(nil)
+
+func (e EncryptedMap) encrypt(key string, data []byte) ([]byte, error) {
+	nonce := make([]byte, encryptionNonceSize)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, encryptedValueHeaderSize, encryptedValueHeaderSize+len(data)+16)
+	copy(buf, encryptedValueMagic)
+	binary.LittleEndian.PutUint32(buf[len(encryptedValueMagic):], e.activeKeyID)
+	copy(buf[len(encryptedValueMagic)+encryptionKeyIDSize:], nonce)
+	return e.aeads[e.activeKeyID].Seal(buf, nonce, data, []byte(key)), nil
+}
+
+func (e EncryptedMap) decrypt(key string, buf []byte) ([]byte, error) {
+	keyID, ok := encryptedValueKeyID(buf)
+	if !ok {
+		if e.readPlaintext {
+			return buf, nil
+		}
+
+		return nil, fmt.Errorf("value not encrypted: %v", key)
+	}
+
+	aead, ok := e.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("encryption key not found: keyID=%v", keyID)
+	}
+
+	nonce := buf[len(encryptedValueMagic)+encryptionKeyIDSize : encryptedValueHeaderSize]
+	data, err := aead.Open(nil, nonce, buf[encryptedValueHeaderSize:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("fail to decrypt %v: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// encryptedValueKeyID returns the ID of the master key the value is encrypted with
+func encryptedValueKeyID(buf []byte) (uint32, bool) {
+	if len(buf) < encryptedValueHeaderSize || !bytes.Equal(buf[:len(encryptedValueMagic)], encryptedValueMagic) {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint32(buf[len(encryptedValueMagic):]), true
+}
+
+func NewEncryptedMap(rawMap RawMap, keys EncryptionKeys) (*EncryptedMap, error) {
+	return newEncryptedMap(rawMap, keys, false)
+}
+
+// NewMigratingEncryptedMap also reads the values written before encryption was enabled,
+// it is only meant for encrypting them.
+func NewMigratingEncryptedMap(rawMap RawMap, keys EncryptionKeys) (*EncryptedMap, error) {
+	return newEncryptedMap(rawMap, keys, true)
+}
+
+func newEncryptedMap(rawMap RawMap, keys EncryptionKeys, readPlaintext bool) (*EncryptedMap, error) {
+	if _, ok := keys.Keys[keys.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key not found: keyID=%v", keys.ActiveKeyID)
+	}
+
+	aeads := make(map[uint32]cipher.AEAD)
+	for keyID, masterKey := range keys.Keys {
+		if len(masterKey) < minMasterKeySize {
+			return nil, fmt.Errorf("encryption key is too short: keyID=%v", keyID)
+		}
+
+		block, err := aes.NewCipher(hkdfSHA256(masterKey, nil, dataKeyInfo, 32))
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		aeads[keyID] = aead
+	}
+
+	encryptedMap := &EncryptedMap{
+		activeKeyID:   keys.ActiveKeyID,
+		aeads:         aeads,
+		readPlaintext: readPlaintext,
+	}
+	encryptedMap.transformedMap = transformedMap{
+		rawMap: rawMap,
+		encode: encryptedMap.encrypt,
+		decode: encryptedMap.decrypt,
+	}
+	return encryptedMap, nil
+}
+
+// Rekey encrypts every value of rawMap which is not yet encrypted with the active key,
+// including the values written before encryption was enabled.
+// It returns the number of rewritten values and should only run while the store is not in use.
+func Rekey(rawMap RawMap, keys EncryptionKeys) (int, error) {
+	encryptedMap, err := NewMigratingEncryptedMap(rawMap, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	iterator, err := Scan(rawMap, "")
+	if err != nil {
+		return 0, err
+	}
+	defer iterator.Close()
+
+	count := 0
+	for iterator.Next() {
+		keyID, ok := encryptedValueKeyID(iterator.Value())
+		if ok && keyID == keys.ActiveKeyID {
+			continue
+		}
+
+		data, err := encryptedMap.decrypt(iterator.Key(), iterator.Value())
+		if err != nil {
+			log.Println(err)
+			return count, err
+		}
+
+		err = encryptedMap.Set(iterator.Key(), data)
+		if err != nil {
+			log.Println(err)
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, iterator.Err()
+}
+
+// hkdfSHA256 derives a key from secret as specified by RFC 5869.
+func hkdfSHA256(secret []byte, salt []byte, info []byte, length int) []byte {
+	if salt == nil {
+		salt = make([]byte, sha256.Size)
+	}
+
+	extractor := hmac.New(sha256.New, salt)
+	extractor.Write(secret)
+	pseudoRandomKey := extractor.Sum(nil)
+
+	output := make([]byte, 0, length+sha256.Size)
+	block := make([]byte, 0)
+	for counter := byte(1); len(output) < length; counter++ {
+		expander := hmac.New(sha256.New, pseudoRandomKey)
+		expander.Write(block)
+		expander.Write(info)
+		expander.Write([]byte{counter})
+		block = expander.Sum(nil)
+		output = append(output, block...)
+	}
+
+	return output[:length]
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHKDFSHA256(t *testing.T) {
+	// RFC 5869 test case 1
+	secret := bytes.Repeat([]byte{0x0b}, 22)
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	expected := "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"
+	assert.Equal(t, expected, hex.EncodeToString(hkdfSHA256(secret, salt, info, 42)))
+}
+
+func TestEncryptedMap(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "keys.json")
+	keys := EncryptionKeys{}
+	_, err := keys.Rotate()
+	assert.Nil(t, err)
+	assert.Nil(t, keys.Save(keyFile))
+
+	keys, err = LoadEncryptionKeys(keyFile)
+	assert.Nil(t, err)
+
+	rawMap := NewInMemoryMap()
+	encryptedMap, err := NewEncryptedMap(rawMap, keys)
+	assert.Nil(t, err)
+	assert.Nil(t, encryptedMap.Set("entities/1/name", []byte("\"Harry\"")))
+
+	stored, err := rawMap.Get("entities/1/name")
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(stored, []byte("Harry")))
+
+	buf, err := encryptedMap.Get("entities/1/name")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\"Harry\""), buf)
+
+	// a value moved to another key is rejected
+	assert.Nil(t, rawMap.Set("entities/2/name", stored))
+	_, err = encryptedMap.Get("entities/2/name")
+	assert.NotNil(t, err)
+
+	// values written without encryption are rejected unless they are being migrated
+	assert.Nil(t, rawMap.Set("entities/3/name", []byte("\"Ron\"")))
+	_, err = encryptedMap.Get("entities/3/name")
+	assert.NotNil(t, err)
+
+	migratingMap, err := NewMigratingEncryptedMap(rawMap, keys)
+	assert.Nil(t, err)
+	buf, err = migratingMap.Get("entities/3/name")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\"Ron\""), buf)
+	assert.Nil(t, rawMap.Delete("entities/2/name"))
+
+	prevKeyID := keys.ActiveKeyID
+	keyID, err := keys.Rotate()
+	assert.Nil(t, err)
+
+	count, err := Rekey(rawMap, keys)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = Rekey(rawMap, keys)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	// the previous key is no longer needed
+	delete(keys.Keys, prevKeyID)
+	encryptedMap, err = NewEncryptedMap(rawMap, keys)
+	assert.Nil(t, err)
+	for key, expected := range map[string]string{"entities/1/name": "\"Harry\"", "entities/3/name": "\"Ron\""} {
+		stored, err = rawMap.Get(key)
+		assert.Nil(t, err)
+
+		storedKeyID, ok := encryptedValueKeyID(stored)
+		assert.True(t, ok)
+		assert.Equal(t, keyID, storedKeyID)
+
+		buf, err = encryptedMap.Get(key)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(expected), buf)
+	}
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrKeyNotFound is matched by the error every RawMap returns from Get when the key doesn't exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+type KeyNotFound string
+
+func (k KeyNotFound) Error() string {
+	return fmt.Sprintf("key not found: %v", (string)(k))
+}
+
+func (k KeyNotFound) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+var _ error = (*KeyNotFound)(nil)
+
+// CorruptedValue is returned when a stored value fails its checksum verification.
+type CorruptedValue string
+
+func (c CorruptedValue) Error() string {
+	return fmt.Sprintf("corrupted value: %v", (string)(c))
+}
+
+var _ error = (*CorruptedValue)(nil)
@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type Operation string
+
+const (
+	GetOperation     Operation = "get"
+	SetOperation     Operation = "set"
+	ContainOperation Operation = "contain"
+	DeleteOperation  Operation = "delete"
+	ScanOperation    Operation = "scan"
+	// CommitOperation applies all the operations staged in a batch
+	CommitOperation Operation = "commit"
+)
+
+func (o Operation) isWrite() bool {
+	return o == SetOperation || o == DeleteOperation || o == CommitOperation
+}
+
+// Fault describes what happens to a single operation of a FaultyMap.
+type Fault struct {
+	// Delay is waited before the operation runs
+	Delay time.Duration
+	// Err is returned instead of running the operation
+	Err error
+	// Crash drops this write and every later write, as if the process stopped before reaching the disk
+	Crash bool
+}
+
+// FaultPlan decides the fault injected into the operation with the given 0-based index.
+type FaultPlan func(index int, operation Operation, key string) Fault
+
+// InjectedFault is the error returned by the failures of a FaultPlan.
+type InjectedFault string
+
+func (i InjectedFault) Error() string {
+	return fmt.Sprintf("injected fault: %v", (string)(i))
+}
+
+var _ error = (*InjectedFault)(nil)
+
+// ScriptedFaultPlan injects the faults at the given operation indexes.
+func ScriptedFaultPlan(faults map[int]Fault) FaultPlan {
+	return func(index int, operation Operation, key string) Fault {
+		return faults[index]
+	}
+}
+
+// FailWritesPlan fails the writes with the given operation indexes.
+func FailWritesPlan(indexes ...int) FaultPlan {
+	failed := make(map[int]bool)
+	for _, index := range indexes {
+		failed[index] = true
+	}
+
+	return func(index int, operation Operation, key string) Fault {
+		if !operation.isWrite() || !failed[index] {
+			return Fault{}
+		}
+
+		return Fault{Err: InjectedFault(fmt.Sprintf("%v %v", operation, key))}
+	}
+}
+
+// CrashPlan crashes at the first write after count operations.
+func CrashPlan(count int) FaultPlan {
+	return func(index int, operation Operation, key string) Fault {
+		return Fault{Crash: index >= count && operation.isWrite()}
+	}
+}
+
+// RandomFaultPlan fails each write with probability failureRate and delays each operation
+// by up to maxDelay. Plans with the same seed inject the same faults for the same operations.
+func RandomFaultPlan(seed int64, failureRate float64, maxDelay time.Duration) FaultPlan {
+	var mut sync.Mutex
+	random := rand.New(rand.NewSource(seed))
+	return func(index int, operation Operation, key string) Fault {
+		mut.Lock()
+		defer mut.Unlock()
+
+		fault := Fault{}
+		if maxDelay > 0 {
+			fault.Delay = time.Duration(random.Int63n(int64(maxDelay)))
+		}
+
+		if random.Float64() < failureRate && operation.isWrite() {
+			fault.Err = InjectedFault(fmt.Sprintf("%v %v", operation, key))
+		}
+
+		return fault
+	}
+}
+
+// FaultyMap injects the faults of a FaultPlan into the operations on the underlying RawMap.
+//
+// Batches are staged in memory and applied atomically to the underlying map on Commit,
+// so a failed or dropped Commit leaves no partial batch behind. Once crashed, writes are
+// silently dropped while reads keep observing what reached the underlying map.
+type FaultyMap struct {
+	mut     sync.Mutex
+	rawMap  RawMap
+	plan    FaultPlan
+	count   int
+	crashed bool
+}
+
+var _ RawMap = (*FaultyMap)(nil)
+
+func (f *FaultyMap) Get(key string) ([]byte, error) {
+	_, err := f.inject(GetOperation, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.rawMap.Get(key)
+}
+
+func (f *FaultyMap) Set(key string, data []byte) error {
+	dropped, err := f.inject(SetOperation, key)
+	if err != nil || dropped {
+		return err
+	}
+
+	return f.rawMap.Set(key, data)
+}
+
+func (f *FaultyMap) Contain(key string) (bool, error) {
+	_, err := f.inject(ContainOperation, key)
+	if err != nil {
+		return false, err
+	}
+
+	return f.rawMap.Contain(key)
+}
+
+func (f *FaultyMap) Delete(key string) error {
+	dropped, err := f.inject(DeleteOperation, key)
+	if err != nil || dropped {
+		return err
+	}
+
+	return f.rawMap.Delete(key)
+}
+
+func (f *FaultyMap) Scan(prefix string) (Iterator, error) {
+	_, err := f.inject(ScanOperation, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return Scan(f.rawMap, prefix)
+}
+
+func (f *FaultyMap) ScanRange(start string, end string) (Iterator, error) {
+	_, err := f.inject(ScanOperation, start)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRange(f.rawMap, start, end)
+}
+
+func (f *FaultyMap) Begin() (Batch, error) {
+	return newStagedBatch(f, f.applyBatch), nil
+}
+
+// Crashed reports whether writes are being dropped.
+func (f *FaultyMap) Crashed() bool {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	return f.crashed
+}
+
+// Operations returns the number of operations seen so far.
+func (f *FaultyMap) Operations() int {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	return f.count
+}
+
+func (f *FaultyMap) applyBatch(ops []batchOp) error {
+	dropped, err := f.inject(CommitOperation, ops[0].key)
+	if err != nil || dropped {
+		return err
+	}
+
+	return RunInBatch(f.rawMap, func(batch RawMap) error {
+		for _, op := range ops {
+			var err error
+			if op.deleted {
+				err = batch.Delete(op.key)
+			} else {
+				err = batch.Set(op.key, op.data)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// inject returns whether the operation is dropped by a crash or the error failing it
+func (f *FaultyMap) inject(operation Operation, key string) (bool, error) {
+	f.mut.Lock()
+	index := f.count
+	f.count++
+	fault := f.plan(index, operation, key)
+	if fault.Crash {
+		f.crashed = true
+	}
+
+	dropped := f.crashed && operation.isWrite()
+	f.mut.Unlock()
+
+	if fault.Delay > 0 {
+		time.Sleep(fault.Delay)
+	}
+
+	if dropped {
+		return true, nil
+	}
+
+	return false, fault.Err
+}
+
+func NewFaultyMap(rawMap RawMap, plan FaultPlan) *FaultyMap {
+	return &FaultyMap{
+		rawMap: rawMap,
+		plan:   plan,
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultyMap(t *testing.T) {
+	rawMap := NewInMemoryMap()
+	faultyMap := NewFaultyMap(rawMap, ScriptedFaultPlan(map[int]Fault{
+		1: {Err: InjectedFault("set")},
+		3: {Crash: true},
+	}))
+
+	assert.Nil(t, faultyMap.Set("map/keys/1", []byte("1")))
+	assert.Equal(t, InjectedFault("set"), faultyMap.Set("map/keys/2", []byte("2")))
+
+	batch, err := faultyMap.Begin()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Set("map/keys/3", []byte("3")))
+	assert.Nil(t, batch.Set("map/keys/4", []byte("4")))
+	assert.Nil(t, batch.Commit())
+	assert.False(t, faultyMap.Crashed())
+
+	// the crashed batch is dropped as a whole
+	batch, err = faultyMap.Begin()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Set("map/keys/5", []byte("5")))
+	assert.Nil(t, batch.Delete("map/keys/1"))
+	assert.Nil(t, batch.Commit())
+	assert.True(t, faultyMap.Crashed())
+	assert.Nil(t, faultyMap.Delete("map/keys/3"))
+
+	for key, expected := range map[string]bool{
+		"map/keys/1": true,
+		"map/keys/2": false,
+		"map/keys/3": true,
+		"map/keys/4": true,
+		"map/keys/5": false,
+	} {
+		contain, err := rawMap.Contain(key)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, contain, key)
+	}
+
+	assert.Equal(t, 5, faultyMap.Operations())
+}
+
+func TestRandomFaultPlan(t *testing.T) {
+	plan1 := RandomFaultPlan(7, 0.5, time.Millisecond)
+	plan2 := RandomFaultPlan(7, 0.5, time.Millisecond)
+	failures := 0
+	for index := 0; index < 100; index++ {
+		fault1 := plan1(index, SetOperation, "key")
+		fault2 := plan2(index, SetOperation, "key")
+		assert.Equal(t, fault1, fault2)
+		assert.True(t, fault1.Delay < time.Millisecond)
+
+		if fault1.Err != nil {
+			failures++
+		}
+	}
+
+	assert.True(t, failures > 0 && failures < 100)
+}
@@ -1,34 +1,105 @@
 package storage
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
 )
 
+type Durability int
+
+const (
+	// DurabilityNone replaces values atomically but leaves flushing to the operating system
+	DurabilityNone Durability = iota
+	// DurabilityData flushes every value to disk before it replaces the previous one
+	DurabilityData
+	// DurabilityFull also flushes the directories, so that new & removed keys survive a power loss
+	DurabilityFull
+)
+
+// fileValueMagic starts with a zero byte which never begins the JSON values written before checksums were added
+var fileValueMagic = []byte{0x00, 'T', 'S', 'V'}
+
+const fileValueHeaderSize = 8 // magic(4) checksum(4)
+
+// checksummedMarker is created in the root directory of a FileMap whose values all carry a checksum
+const checksummedMarker = ".checksummed"
+
 type FileMap struct {
-	rootDir string
+	batchMut   sync.Mutex
+	rootDir    string
+	durability Durability
+	// legacyValues is set when the values written before checksums were added can exist
+	legacyValues bool
 }
 
 var _ RawMap = (*FileMap)(nil)
 
-func (f FileMap) Get(key string) ([]byte, error) {
-	return ioutil.ReadFile(path.Join(f.rootDir, key))
+func (f *FileMap) Get(key string) ([]byte, error) {
+	buf, err := ioutil.ReadFile(path.Join(f.rootDir, key))
+	if os.IsNotExist(err) {
+		return nil, KeyNotFound(key)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeFileValue(key, buf, f.legacyValues)
 }
 
-func (f FileMap) Set(key string, data []byte) error {
+// Set writes the value into a temporary file which then atomically replaces the previous value.
+func (f *FileMap) Set(key string, data []byte) error {
 	filePath := path.Join(f.rootDir, key)
 	dir := filepath.Dir(filePath)
-	err := os.MkdirAll(dir, os.ModePerm)
+	err := f.makeDir(dir)
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(dir, filepath.Base(filePath)+".*"+tmpExt)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(encodeFileValue(data))
+	if err == nil {
+		err = file.Chmod(0644)
+	}
+
+	if err == nil && f.durability >= DurabilityData {
+		err = file.Sync()
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), filePath)
+	}
+
 	if err != nil {
+		os.Remove(file.Name())
 		return err
 	}
 
-	return ioutil.WriteFile(filePath, data, os.ModePerm)
+	if f.durability < DurabilityFull {
+		return nil
+	}
+
+	return syncDir(dir)
 }
 
-func (f FileMap) Contain(key string) (bool, error) {
+func (f *FileMap) Contain(key string) (bool, error) {
 	_, err := os.Stat(path.Join(f.rootDir, key))
 	if err == nil {
 		return true, nil
@@ -41,12 +112,224 @@ func (f FileMap) Contain(key string) (bool, error) {
 	return false, err
 }
 
-func (f FileMap) Delete(key string) error {
-	return os.RemoveAll(path.Join(f.rootDir, key))
+func (f *FileMap) Delete(key string) error {
+	filePath := path.Join(f.rootDir, key)
+	err := os.RemoveAll(filePath)
+	if err != nil || f.durability < DurabilityFull {
+		return err
+	}
+
+	err = syncDir(filepath.Dir(filePath))
+	if os.IsNotExist(err) {
+		// nothing was removed
+		return nil
+	}
+
+	return err
+}
+
+func (f *FileMap) Scan(prefix string) (Iterator, error) {
+	return f.ScanRange(prefix, prefixEnd(prefix))
+}
+
+func (f *FileMap) ScanRange(start string, end string) (Iterator, error) {
+	keys := make([]string, 0)
+	err := filepath.Walk(path.Join(f.rootDir, commonDir(start, end)), func(filePath string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(f.rootDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(relPath)
+		if key == journalExt || key == checksummedMarker || strings.HasSuffix(key, tmpExt) {
+			return nil
+		}
+
+		if inRange(key, start, end) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSnapshotIterator(keys, func(key string) ([]byte, bool, error) {
+		buf, err := f.Get(key)
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil, false, nil
+		}
+
+		return buf, err == nil, err
+	}), nil
+}
+
+// Begin starts a batch which is journaled before being applied,
+// so that a batch interrupted by a crash is redone when the map is opened again.
+func (f *FileMap) Begin() (Batch, error) {
+	return newStagedBatch(f, func(ops []batchOp) error {
+		f.batchMut.Lock()
+		defer f.batchMut.Unlock()
+
+		err := writeJournal(f.journalPath(), ops)
+		if err != nil {
+			return err
+		}
+
+		return f.redo(ops)
+	}), nil
 }
 
-func NewFileMap(rootDir string) *FileMap {
-	return &FileMap{
-		rootDir: rootDir,
+// makeDir creates the missing directories from the root directory down to dir
+func (f *FileMap) makeDir(dir string) error {
+	_, err := os.Stat(dir)
+	if err == nil || !os.IsNotExist(err) {
+		return err
 	}
+
+	parent := filepath.Dir(dir)
+	err = f.makeDir(parent)
+	if err != nil {
+		return err
+	}
+
+	err = os.Mkdir(dir, os.ModePerm)
+	if err != nil && !os.IsExist(err) {
+		return err
+	}
+
+	if f.durability < DurabilityFull {
+		return nil
+	}
+
+	return syncDir(parent)
+}
+
+func (f *FileMap) redo(ops []batchOp) error {
+	for _, op := range ops {
+		var err error
+		if op.deleted {
+			err = f.Delete(op.key)
+		} else {
+			err = f.Set(op.key, op.data)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return removeJournal(f.journalPath())
+}
+
+func (f *FileMap) recover() error {
+	ops, ok, err := readJournal(f.journalPath())
+	if err != nil || !ok {
+		return err
+	}
+
+	return f.redo(ops)
+}
+
+func (f *FileMap) journalPath() string {
+	return path.Join(f.rootDir, journalExt)
+}
+
+func encodeFileValue(data []byte) []byte {
+	buf := make([]byte, fileValueHeaderSize, fileValueHeaderSize+len(data))
+	copy(buf, fileValueMagic)
+	binary.LittleEndian.PutUint32(buf[len(fileValueMagic):], crc32.ChecksumIEEE(data))
+	return append(buf, data...)
+}
+
+func decodeFileValue(key string, buf []byte, legacyValues bool) ([]byte, error) {
+	if legacyValues && !hasFileValueMagic(buf) {
+		// written before checksums were added
+		return buf, nil
+	}
+
+	if len(buf) < fileValueHeaderSize || !bytes.Equal(buf[:len(fileValueMagic)], fileValueMagic) {
+		return nil, CorruptedValue(key)
+	}
+
+	data := buf[fileValueHeaderSize:]
+	if binary.LittleEndian.Uint32(buf[len(fileValueMagic):fileValueHeaderSize]) != crc32.ChecksumIEEE(data) {
+		return nil, CorruptedValue(key)
+	}
+
+	return data, nil
+}
+
+// hasFileValueMagic tells whether the value starts with the magic, a corrupted leading byte is tolerated
+// since it would otherwise pass the value off as one written before checksums were added.
+func hasFileValueMagic(buf []byte) bool {
+	if len(buf) > 0 && buf[0] == fileValueMagic[0] {
+		return true
+	}
+
+	return len(buf) >= len(fileValueMagic) && bytes.Equal(buf[1:len(fileValueMagic)], fileValueMagic[1:])
+}
+
+// openChecksummed tells whether every value of the root directory carries a checksum,
+// which is the case for the directories created since checksums were added.
+func openChecksummed(rootDir string) (bool, error) {
+	markerPath := path.Join(rootDir, checksummedMarker)
+	_, err := os.Stat(markerPath)
+	if err == nil {
+		return true, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	fileInfos, err := ioutil.ReadDir(rootDir)
+	if err != nil || len(fileInfos) > 0 {
+		return false, err
+	}
+
+	err = ioutil.WriteFile(markerPath, nil, 0644)
+	if err != nil {
+		return false, err
+	}
+
+	return true, syncDir(rootDir)
+}
+
+func NewFileMap(rootDir string, durability Durability) (*FileMap, error) {
+	err := os.MkdirAll(rootDir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	checksummed, err := openChecksummed(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	fileMap := &FileMap{
+		rootDir:      rootDir,
+		durability:   durability,
+		legacyValues: !checksummed,
+	}
+
+	err = fileMap.recover()
+	if err != nil {
+		return nil, err
+	}
+
+	return fileMap, nil
 }
@@ -1,32 +1,116 @@
 package storage
 
+import (
+	"strings"
+	"sync"
+)
+
 type InMemoryMap struct {
+	mut  *sync.RWMutex
 	data map[string][]byte
 }
 
 var _ RawMap = (*InMemoryMap)(nil)
 
 func (i InMemoryMap) Get(key string) ([]byte, error) {
-	return i.data[key], nil
+	i.mut.RLock()
+	defer i.mut.RUnlock()
+
+	data, ok := i.data[key]
+	if !ok {
+		return nil, KeyNotFound(key)
+	}
+
+	return data, nil
 }
 
 func (i InMemoryMap) Set(key string, data []byte) error {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
 	i.data[key] = data
 	return nil
 }
 
 func (i InMemoryMap) Contain(key string) (bool, error) {
+	i.mut.RLock()
+	defer i.mut.RUnlock()
+
 	_, ok := i.data[key]
-	return ok, nil
+	if ok {
+		return true, nil
+	}
+
+	for currKey := range i.data {
+		if strings.HasPrefix(currKey, key+"/") {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
+// Delete removes the key together with all the keys nested under it.
 func (i InMemoryMap) Delete(key string) error {
-	delete(i.data, key)
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	i.delete(key)
 	return nil
 }
 
+func (i InMemoryMap) Scan(prefix string) (Iterator, error) {
+	return i.ScanRange(prefix, prefixEnd(prefix))
+}
+
+func (i InMemoryMap) ScanRange(start string, end string) (Iterator, error) {
+	i.mut.RLock()
+	keys := make([]string, 0)
+	for key := range i.data {
+		if inRange(key, start, end) {
+			keys = append(keys, key)
+		}
+	}
+	i.mut.RUnlock()
+
+	return newSnapshotIterator(keys, func(key string) ([]byte, bool, error) {
+		i.mut.RLock()
+		defer i.mut.RUnlock()
+
+		value, ok := i.data[key]
+		return value, ok, nil
+	}), nil
+}
+
+func (i InMemoryMap) Begin() (Batch, error) {
+	return newStagedBatch(i, func(ops []batchOp) error {
+		i.mut.Lock()
+		defer i.mut.Unlock()
+
+		for _, op := range ops {
+			if op.deleted {
+				i.delete(op.key)
+			} else {
+				i.data[op.key] = op.data
+			}
+		}
+
+		return nil
+	}), nil
+}
+
+func (i InMemoryMap) delete(key string) {
+	delete(i.data, key)
+	for currKey := range i.data {
+		if strings.HasPrefix(currKey, key+"/") {
+			delete(i.data, currKey)
+		}
+	}
+}
+
 func NewInMemoryMap() InMemoryMap {
 	return InMemoryMap{
+		mut:  &sync.RWMutex{},
 		data: map[string][]byte{},
 	}
 }
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	journalExt = ".journal"
+	tmpExt     = ".tmp"
+)
+
+const journalOpHeaderSize = 9 // deleted(1) key length(4) data length(4)
+
+// writeJournal durably records the batch so that it can be redone after a crash.
+// The journal only becomes visible once it is completely written.
+func writeJournal(journalPath string, ops []batchOp) error {
+	buf := make([]byte, 0)
+	for _, op := range ops {
+		header := make([]byte, journalOpHeaderSize)
+		if op.deleted {
+			header[0] = 1
+		}
+
+		binary.LittleEndian.PutUint32(header[1:5], uint32(len(op.key)))
+		binary.LittleEndian.PutUint32(header[5:9], uint32(len(op.data)))
+		buf = append(buf, header...)
+		buf = append(buf, op.key...)
+		buf = append(buf, op.data...)
+	}
+
+	checksum := make([]byte, 4)
+	binary.LittleEndian.PutUint32(checksum, crc32.ChecksumIEEE(buf))
+	buf = append(buf, checksum...)
+
+	tmpPath := journalPath + tmpExt
+	file, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(buf)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return closeErr
+	}
+
+	err = os.Rename(tmpPath, journalPath)
+	if err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(journalPath))
+}
+
+// readJournal returns the operations of a batch which was committed but may not be fully applied.
+func readJournal(journalPath string) ([]batchOp, bool, error) {
+	buf, err := ioutil.ReadFile(journalPath)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(buf) < 4 {
+		return nil, false, errors.New("corrupted journal")
+	}
+
+	body := buf[:len(buf)-4]
+	if binary.LittleEndian.Uint32(buf[len(buf)-4:]) != crc32.ChecksumIEEE(body) {
+		return nil, false, errors.New("corrupted journal")
+	}
+
+	ops := make([]batchOp, 0)
+	for offset := 0; offset < len(body); {
+		if offset+journalOpHeaderSize > len(body) {
+			return nil, false, errors.New("corrupted journal")
+		}
+
+		header := body[offset : offset+journalOpHeaderSize]
+		keyLen := int(binary.LittleEndian.Uint32(header[1:5]))
+		dataLen := int(binary.LittleEndian.Uint32(header[5:9]))
+		keyStart := offset + journalOpHeaderSize
+		if keyStart+keyLen+dataLen > len(body) {
+			return nil, false, errors.New("corrupted journal")
+		}
+
+		ops = append(ops, batchOp{
+			key:     string(body[keyStart : keyStart+keyLen]),
+			data:    body[keyStart+keyLen : keyStart+keyLen+dataLen],
+			deleted: header[0] == 1,
+		})
+		offset = keyStart + keyLen + dataLen
+	}
+
+	return ops, true, nil
+}
+
+func removeJournal(journalPath string) error {
+	err := os.Remove(journalPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return syncDir(filepath.Dir(journalPath))
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"math/rand"
+	"strings"
+)
+
+const keyIndexMaxLevel = 24
+
+type keyIndexNode[Value any] struct {
+	key   string
+	value Value
+	next  []*keyIndexNode[Value]
+}
+
+// keyIndex is an in-memory skip list keeping keys in lexical order.
+// It is used by the single file backends to answer both point lookups and
+// directory style lookups ("a/b" contains "a/b/c").
+type keyIndex[Value any] struct {
+	head   *keyIndexNode[Value]
+	level  int
+	length int
+	random *rand.Rand
+}
+
+func (k *keyIndex[Value]) Get(key string) (Value, bool) {
+	node := k.seek(key)
+	if node == nil || node.key != key {
+		return *new(Value), false
+	}
+
+	return node.value, true
+}
+
+func (k *keyIndex[Value]) Set(key string, value Value) {
+	update := k.predecessors(key)
+	next := update[0].next[0]
+	if next != nil && next.key == key {
+		next.value = value
+		return
+	}
+
+	level := k.randomLevel()
+	if level > k.level {
+		for index := k.level; index < level; index++ {
+			update[index] = k.head
+		}
+
+		k.level = level
+	}
+
+	node := &keyIndexNode[Value]{
+		key:   key,
+		value: value,
+		next:  make([]*keyIndexNode[Value], level),
+	}
+	for index := 0; index < level; index++ {
+		node.next[index] = update[index].next[index]
+		update[index].next[index] = node
+	}
+
+	k.length++
+}
+
+func (k *keyIndex[Value]) Delete(key string) (Value, bool) {
+	update := k.predecessors(key)
+	node := update[0].next[0]
+	if node == nil || node.key != key {
+		return *new(Value), false
+	}
+
+	for index := 0; index < k.level; index++ {
+		if update[index].next[index] != node {
+			break
+		}
+
+		update[index].next[index] = node.next[index]
+	}
+
+	for k.level > 1 && k.head.next[k.level-1] == nil {
+		k.level--
+	}
+
+	k.length--
+	return node.value, true
+}
+
+// ContainDir returns whether the key exists or any key is nested under it.
+func (k *keyIndex[Value]) ContainDir(key string) bool {
+	if _, ok := k.Get(key); ok {
+		return true
+	}
+
+	node := k.seek(key + "/")
+	return node != nil && strings.HasPrefix(node.key, key+"/")
+}
+
+// KeysUnder returns the key itself, when present, followed by every key nested under it.
+func (k *keyIndex[Value]) KeysUnder(key string) []string {
+	keys := make([]string, 0)
+	if _, ok := k.Get(key); ok {
+		keys = append(keys, key)
+	}
+
+	dirPrefix := key + "/"
+	for node := k.seek(dirPrefix); node != nil && strings.HasPrefix(node.key, dirPrefix); node = node.next[0] {
+		keys = append(keys, node.key)
+	}
+
+	return keys
+}
+
+// NextKey returns the first key larger than the given key, or equal to it when inclusive is set.
+func (k *keyIndex[Value]) NextKey(key string, inclusive bool) (string, bool) {
+	node := k.seek(key)
+	if node != nil && !inclusive && node.key == key {
+		node = node.next[0]
+	}
+
+	if node == nil {
+		return "", false
+	}
+
+	return node.key, true
+}
+
+func (k *keyIndex[Value]) Len() int {
+	return k.length
+}
+
+// seek returns the first node whose key is larger than or equal to the given key.
+func (k *keyIndex[Value]) seek(key string) *keyIndexNode[Value] {
+	return k.predecessors(key)[0].next[0]
+}
+
+func (k *keyIndex[Value]) predecessors(key string) []*keyIndexNode[Value] {
+	update := make([]*keyIndexNode[Value], keyIndexMaxLevel)
+	curr := k.head
+	for level := k.level - 1; level >= 0; level-- {
+		for curr.next[level] != nil && curr.next[level].key < key {
+			curr = curr.next[level]
+		}
+
+		update[level] = curr
+	}
+
+	return update
+}
+
+func (k *keyIndex[Value]) randomLevel() int {
+	level := 1
+	for level < keyIndexMaxLevel && k.random.Intn(4) == 0 {
+		level++
+	}
+
+	return level
+}
+
+func newKeyIndex[Value any]() *keyIndex[Value] {
+	return &keyIndex[Value]{
+		head: &keyIndexNode[Value]{
+			next: make([]*keyIndexNode[Value], keyIndexMaxLevel),
+		},
+		level:  1,
+		random: rand.New(rand.NewSource(1)),
+	}
+}
@@ -0,0 +1,818 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	logRecordHeaderSize = 13 // checksum(4) flags(1) key length(4) value length(4)
+	logHintHeaderSize   = 17 // flags(1) key length(4) value length(4) record offset(8)
+)
+
+const (
+	segmentExt = ".seg"
+	hintExt    = ".hint"
+	mergeExt   = ".merge"
+)
+
+const (
+	tombstoneRecordFlag byte = 1 << iota
+	// batchRecordFlag marks a record which is followed by more records of the same batch
+	batchRecordFlag
+)
+
+type LogMapOptions struct {
+	// MaxSegmentSize is the size after which the active segment is closed and a new one is started
+	MaxSegmentSize int64
+	// CompactionInterval is how often the dead records ratio is checked, 0 disables background compaction
+	CompactionInterval time.Duration
+	// CompactionRatio is the minimum ratio of dead bytes to total bytes which triggers a compaction
+	CompactionRatio float64
+}
+
+func DefaultLogMapOptions() LogMapOptions {
+	return LogMapOptions{
+		MaxSegmentSize:     64 << 20,
+		CompactionInterval: time.Minute,
+		CompactionRatio:    0.5,
+	}
+}
+
+type logLocation struct {
+	segment  uint32
+	offset   int64
+	keyLen   uint32
+	valueLen uint32
+}
+
+func (l logLocation) size() int64 {
+	return logRecordHeaderSize + int64(l.keyLen) + int64(l.valueLen)
+}
+
+type logSegment struct {
+	id   uint32
+	file *os.File
+	size int64
+}
+
+// LogMap is a log structured RawMap.
+//
+// Every write is appended to the active segment file and an in-memory key directory points each key
+// to its latest record. Closed segments are merged in the background: live records are copied into a
+// new segment together with a hint file, which lets the key directory be rebuilt on startup without
+// reading the values.
+type LogMap struct {
+	mut        sync.RWMutex
+	compactMut sync.Mutex
+	dir        string
+	options    LogMapOptions
+	index      *keyIndex[logLocation]
+	segments   map[uint32]*logSegment
+	active     *logSegment
+	totalBytes int64
+	liveBytes  int64
+	stop       chan struct{}
+	stopped    chan struct{}
+}
+
+var _ RawMap = (*LogMap)(nil)
+
+type logRecord struct {
+	flags byte
+	key   string
+	value []byte
+}
+
+func (l *LogMap) Get(key string) ([]byte, error) {
+	value, ok, err := l.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, KeyNotFound(key)
+	}
+
+	return value, nil
+}
+
+func (l *LogMap) Set(key string, data []byte) error {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	location, err := l.append(logRecord{key: key, value: data})
+	if err != nil {
+		return err
+	}
+
+	err = l.active.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	l.put(key, location)
+	return l.rotate()
+}
+
+func (l *LogMap) Contain(key string) (bool, error) {
+	l.mut.RLock()
+	defer l.mut.RUnlock()
+
+	return l.index.ContainDir(key), nil
+}
+
+// Delete removes the key together with all the keys nested under it.
+func (l *LogMap) Delete(key string) error {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	keys := l.index.KeysUnder(key)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, currKey := range keys {
+		_, err := l.append(logRecord{flags: tombstoneRecordFlag, key: currKey})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := l.active.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	for _, currKey := range keys {
+		l.remove(currKey)
+	}
+
+	return l.rotate()
+}
+
+func (l *LogMap) Scan(prefix string) (Iterator, error) {
+	return l.ScanRange(prefix, prefixEnd(prefix))
+}
+
+func (l *LogMap) ScanRange(start string, end string) (Iterator, error) {
+	return newCursorIterator(start, end, func(key string, inclusive bool) (string, bool) {
+		l.mut.RLock()
+		defer l.mut.RUnlock()
+
+		return l.index.NextKey(key, inclusive)
+	}, l.lookup), nil
+}
+
+// Begin starts a batch whose records are appended together and synced once.
+// Every record of a batch except the last one is flagged, so a batch cut short by a crash is discarded on load.
+func (l *LogMap) Begin() (Batch, error) {
+	return newStagedBatch(l, l.applyBatch), nil
+}
+
+// Compact merges all closed segments into a single segment containing only live records.
+// It is safe to call while other goroutines are reading or writing.
+func (l *LogMap) Compact() error {
+	l.compactMut.Lock()
+	defer l.compactMut.Unlock()
+
+	l.mut.Lock()
+	mergeID := l.active.id + 1
+	err := l.openActiveSegment(mergeID + 1)
+	if err != nil {
+		l.mut.Unlock()
+		return err
+	}
+
+	inputs := make(map[uint32]*logSegment)
+	for id, segment := range l.segments {
+		if id < mergeID {
+			inputs[id] = segment
+		}
+	}
+
+	keys := make([]string, 0)
+	oldLocations := make([]logLocation, 0)
+	for node := l.index.head.next[0]; node != nil; node = node.next[0] {
+		if _, ok := inputs[node.value.segment]; ok {
+			keys = append(keys, node.key)
+			oldLocations = append(oldLocations, node.value)
+		}
+	}
+	l.mut.Unlock()
+
+	newLocations, err := l.writeMergedSegment(mergeID, inputs, keys, oldLocations)
+	if err != nil {
+		os.Remove(l.segmentPath(mergeID, mergeExt))
+		os.Remove(l.segmentPath(mergeID, hintExt))
+		return err
+	}
+
+	merged, err := openLogSegment(l.segmentPath(mergeID, segmentExt), mergeID)
+	if err != nil {
+		return err
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.segments[mergeID] = merged
+	l.totalBytes += merged.size
+	for index, key := range keys {
+		// skip the keys overwritten or deleted while merging
+		location, ok := l.index.Get(key)
+		if ok && location == oldLocations[index] {
+			l.index.Set(key, newLocations[index])
+		}
+	}
+
+	for id, segment := range inputs {
+		l.totalBytes -= segment.size
+		delete(l.segments, id)
+		segment.file.Close()
+		os.Remove(l.segmentPath(id, segmentExt))
+		os.Remove(l.segmentPath(id, hintExt))
+	}
+
+	return nil
+}
+
+func (l *LogMap) Close() error {
+	if l.stop != nil {
+		close(l.stop)
+		<-l.stopped
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	var err error
+	for _, segment := range l.segments {
+		closeErr := segment.file.Close()
+		if closeErr != nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+func (l *LogMap) writeMergedSegment(
+	mergeID uint32,
+	inputs map[uint32]*logSegment,
+	keys []string,
+	oldLocations []logLocation,
+) ([]logLocation, error) {
+	mergeFile, err := os.OpenFile(l.segmentPath(mergeID, mergeExt), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer mergeFile.Close()
+
+	hintFile, err := os.OpenFile(l.segmentPath(mergeID, hintExt), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer hintFile.Close()
+
+	mergeWriter := bufio.NewWriter(mergeFile)
+	hintWriter := bufio.NewWriter(hintFile)
+	newLocations := make([]logLocation, len(keys))
+	var offset int64
+	for index, oldLocation := range oldLocations {
+		record, err := readLogRecord(inputs[oldLocation.segment].file, oldLocation)
+		if err != nil {
+			return nil, err
+		}
+
+		record.flags &^= batchRecordFlag
+		buf := encodeLogRecord(record)
+		_, err = mergeWriter.Write(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		newLocations[index] = logLocation{
+			segment:  mergeID,
+			offset:   offset,
+			keyLen:   oldLocation.keyLen,
+			valueLen: oldLocation.valueLen,
+		}
+		_, err = hintWriter.Write(encodeLogHint(keys[index], newLocations[index]))
+		if err != nil {
+			return nil, err
+		}
+
+		offset += int64(len(buf))
+	}
+
+	for _, file := range []struct {
+		writer *bufio.Writer
+		file   *os.File
+	}{{mergeWriter, mergeFile}, {hintWriter, hintFile}} {
+		err = file.writer.Flush()
+		if err != nil {
+			return nil, err
+		}
+
+		err = file.file.Sync()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the merged segment only becomes visible on restart once both the segment and the hint are durable
+	err = os.Rename(l.segmentPath(mergeID, mergeExt), l.segmentPath(mergeID, segmentExt))
+	if err != nil {
+		return nil, err
+	}
+
+	return newLocations, syncDir(l.dir)
+}
+
+func (l *LogMap) lookup(key string) ([]byte, bool, error) {
+	l.mut.RLock()
+	defer l.mut.RUnlock()
+
+	location, ok := l.index.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	record, err := readLogRecord(l.segments[location.segment].file, location)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return record.value, true, nil
+}
+
+func (l *LogMap) applyBatch(ops []batchOp) error {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	records := l.batchRecords(ops)
+	start := l.active.size
+	locations := make([]logLocation, len(records))
+	for index, record := range records {
+		if index < len(records)-1 {
+			record.flags |= batchRecordFlag
+		}
+
+		location, err := l.append(record)
+		if err != nil {
+			l.discardTail(start)
+			return err
+		}
+
+		locations[index] = location
+	}
+
+	err := l.active.file.Sync()
+	if err != nil {
+		l.discardTail(start)
+		return err
+	}
+
+	for index, record := range records {
+		if record.flags&tombstoneRecordFlag != 0 {
+			l.remove(record.key)
+		} else {
+			l.put(record.key, locations[index])
+		}
+	}
+
+	return l.rotate()
+}
+
+// batchRecords returns the final state of every key touched by the batch
+func (l *LogMap) batchRecords(ops []batchOp) []logRecord {
+	staged := make(map[string]logRecord)
+	for _, op := range ops {
+		if !op.deleted {
+			staged[op.key] = logRecord{key: op.key, value: op.data}
+			continue
+		}
+
+		for _, key := range l.index.KeysUnder(op.key) {
+			staged[key] = logRecord{flags: tombstoneRecordFlag, key: key}
+		}
+
+		for key := range staged {
+			if isSameOrNested(key, op.key) {
+				staged[key] = logRecord{flags: tombstoneRecordFlag, key: key}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(staged))
+	for key := range staged {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	records := make([]logRecord, len(keys))
+	for index, key := range keys {
+		records[index] = staged[key]
+	}
+
+	return records
+}
+
+// discardTail drops the records of a failed batch so that they are never mistaken for a complete batch
+func (l *LogMap) discardTail(offset int64) {
+	err := l.active.file.Truncate(offset)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	l.totalBytes -= l.active.size - offset
+	l.active.size = offset
+}
+
+func (l *LogMap) append(record logRecord) (logLocation, error) {
+	buf := encodeLogRecord(record)
+	location := logLocation{
+		segment:  l.active.id,
+		offset:   l.active.size,
+		keyLen:   uint32(len(record.key)),
+		valueLen: uint32(len(record.value)),
+	}
+
+	_, err := l.active.file.WriteAt(buf, l.active.size)
+	if err != nil {
+		return logLocation{}, err
+	}
+
+	l.active.size += int64(len(buf))
+	l.totalBytes += int64(len(buf))
+	return location, nil
+}
+
+func (l *LogMap) put(key string, location logLocation) {
+	l.remove(key)
+	l.index.Set(key, location)
+	l.liveBytes += location.size()
+}
+
+func (l *LogMap) remove(key string) {
+	prevLocation, ok := l.index.Delete(key)
+	if ok {
+		l.liveBytes -= prevLocation.size()
+	}
+}
+
+func (l *LogMap) rotate() error {
+	if l.active.size < l.options.MaxSegmentSize {
+		return nil
+	}
+
+	return l.openActiveSegment(l.active.id + 1)
+}
+
+func (l *LogMap) openActiveSegment(id uint32) error {
+	segment, err := openLogSegment(l.segmentPath(id, segmentExt), id)
+	if err != nil {
+		return err
+	}
+
+	l.segments[id] = segment
+	l.active = segment
+	return syncDir(l.dir)
+}
+
+func (l *LogMap) compactPeriodically() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.options.CompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.mut.RLock()
+			deadBytes := l.totalBytes - l.liveBytes
+			shouldCompact := len(l.segments) > 1 &&
+				l.totalBytes > 0 &&
+				float64(deadBytes)/float64(l.totalBytes) >= l.options.CompactionRatio
+			l.mut.RUnlock()
+
+			if !shouldCompact {
+				continue
+			}
+
+			err := l.Compact()
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+func (l *LogMap) load() error {
+	fileInfos, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return err
+	}
+
+	segmentIDs := make([]uint32, 0)
+	hints := make(map[uint32]bool)
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		ext := filepath.Ext(name)
+		var id uint32
+		_, err = fmt.Sscanf(strings.TrimSuffix(name, ext), "%d", &id)
+		if err != nil {
+			continue
+		}
+
+		switch ext {
+		case segmentExt:
+			segmentIDs = append(segmentIDs, id)
+		case hintExt:
+			hints[id] = true
+		case mergeExt:
+			// unfinished merge
+			err = os.Remove(filepath.Join(l.dir, name))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	sort.Slice(segmentIDs, func(i, j int) bool {
+		return segmentIDs[i] < segmentIDs[j]
+	})
+
+	// a merged segment contains every live record of the segments before it
+	baseIndex := 0
+	for index, id := range segmentIDs {
+		if hints[id] {
+			baseIndex = index
+		}
+	}
+
+	for _, id := range segmentIDs[:baseIndex] {
+		os.Remove(l.segmentPath(id, segmentExt))
+		os.Remove(l.segmentPath(id, hintExt))
+	}
+
+	var lastID uint32
+	for _, id := range segmentIDs[baseIndex:] {
+		segment, err := openLogSegment(l.segmentPath(id, segmentExt), id)
+		if err != nil {
+			return err
+		}
+
+		lastID = id
+		if segment.size == 0 && !hints[id] {
+			segment.file.Close()
+			os.Remove(l.segmentPath(id, segmentExt))
+			continue
+		}
+
+		l.segments[id] = segment
+		if hints[id] {
+			err = l.loadHints(segment)
+		} else {
+			err = l.loadSegment(segment)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		l.totalBytes += segment.size
+	}
+
+	return l.openActiveSegment(lastID + 1)
+}
+
+func (l *LogMap) loadHints(segment *logSegment) error {
+	buf, err := ioutil.ReadFile(l.segmentPath(segment.id, hintExt))
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset+logHintHeaderSize <= len(buf); {
+		header := buf[offset : offset+logHintHeaderSize]
+		keyLen := binary.LittleEndian.Uint32(header[1:5])
+		keyStart := offset + logHintHeaderSize
+		if keyStart+int(keyLen) > len(buf) {
+			return fmt.Errorf("corrupted hint file: segment=%v", segment.id)
+		}
+
+		location := logLocation{
+			segment:  segment.id,
+			offset:   int64(binary.LittleEndian.Uint64(header[9:17])),
+			keyLen:   keyLen,
+			valueLen: binary.LittleEndian.Uint32(header[5:9]),
+		}
+		l.put(string(buf[keyStart:keyStart+int(keyLen)]), location)
+		offset = keyStart + int(keyLen)
+	}
+
+	return nil
+}
+
+func (l *LogMap) loadSegment(segment *logSegment) error {
+	reader := bufio.NewReader(io.NewSectionReader(segment.file, 0, segment.size))
+	var offset int64
+	var batchOffset int64
+	pending := make([]logRecord, 0)
+	pendingLocations := make([]logLocation, 0)
+	for {
+		record, size, err := decodeLogRecord(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			log.Printf("[LogMap] torn record: segment=%v offset=%v error=%v\n", segment.id, offset, err)
+			break
+		}
+
+		if len(pending) == 0 {
+			batchOffset = offset
+		}
+
+		pending = append(pending, record)
+		pendingLocations = append(pendingLocations, logLocation{
+			segment:  segment.id,
+			offset:   offset,
+			keyLen:   uint32(len(record.key)),
+			valueLen: uint32(len(record.value)),
+		})
+		offset += size
+		if record.flags&batchRecordFlag != 0 {
+			continue
+		}
+
+		for index, record := range pending {
+			if record.flags&tombstoneRecordFlag != 0 {
+				l.remove(record.key)
+			} else {
+				l.put(record.key, pendingLocations[index])
+			}
+		}
+
+		pending = pending[:0]
+		pendingLocations = pendingLocations[:0]
+	}
+
+	if len(pending) > 0 {
+		// the batch was not completely written before the crash
+		offset = batchOffset
+	}
+
+	if offset == segment.size {
+		return nil
+	}
+
+	// drop the torn tail left by a crash
+	log.Printf("[LogMap] truncate segment: segment=%v offset=%v\n", segment.id, offset)
+	err := segment.file.Truncate(offset)
+	if err != nil {
+		return err
+	}
+
+	segment.size = offset
+	return nil
+}
+
+func (l *LogMap) segmentPath(id uint32, ext string) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%010d%s", id, ext))
+}
+
+func openLogSegment(filePath string, id uint32) (*logSegment, error) {
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &logSegment{
+		id:   id,
+		file: file,
+		size: info.Size(),
+	}, nil
+}
+
+func encodeLogRecord(record logRecord) []byte {
+	buf := make([]byte, logRecordHeaderSize, logRecordHeaderSize+len(record.key)+len(record.value))
+	buf[4] = record.flags
+	binary.LittleEndian.PutUint32(buf[5:9], uint32(len(record.key)))
+	binary.LittleEndian.PutUint32(buf[9:13], uint32(len(record.value)))
+	buf = append(buf, record.key...)
+	buf = append(buf, record.value...)
+	binary.LittleEndian.PutUint32(buf[0:4], crc32.ChecksumIEEE(buf[4:]))
+	return buf
+}
+
+func decodeLogRecord(reader io.Reader) (logRecord, int64, error) {
+	header := make([]byte, logRecordHeaderSize)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return logRecord{}, 0, errors.New("incomplete record header")
+		}
+
+		return logRecord{}, 0, err
+	}
+
+	keyLen := binary.LittleEndian.Uint32(header[5:9])
+	valueLen := binary.LittleEndian.Uint32(header[9:13])
+	body := make([]byte, int(keyLen)+int(valueLen))
+	_, err = io.ReadFull(reader, body)
+	if err != nil {
+		return logRecord{}, 0, errors.New("incomplete record body")
+	}
+
+	checksum := crc32.NewIEEE()
+	checksum.Write(header[4:])
+	checksum.Write(body)
+	if checksum.Sum32() != binary.LittleEndian.Uint32(header[0:4]) {
+		return logRecord{}, 0, errors.New("record checksum mismatch")
+	}
+
+	return logRecord{
+		flags: header[4],
+		key:   string(body[:keyLen]),
+		value: body[keyLen:],
+	}, int64(logRecordHeaderSize + len(body)), nil
+}
+
+func readLogRecord(file *os.File, location logLocation) (logRecord, error) {
+	record, _, err := decodeLogRecord(io.NewSectionReader(file, location.offset, location.size()))
+	if err != nil {
+		return logRecord{}, fmt.Errorf("fail to read record: segment=%v offset=%v: %w", location.segment, location.offset, err)
+	}
+
+	return record, nil
+}
+
+func encodeLogHint(key string, location logLocation) []byte {
+	buf := make([]byte, logHintHeaderSize, logHintHeaderSize+len(key))
+	binary.LittleEndian.PutUint32(buf[1:5], location.keyLen)
+	binary.LittleEndian.PutUint32(buf[5:9], location.valueLen)
+	binary.LittleEndian.PutUint64(buf[9:17], uint64(location.offset))
+	return append(buf, key...)
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return file.Sync()
+}
+
+func NewLogMap(dir string, options LogMapOptions) (*LogMap, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	logMap := &LogMap{
+		dir:      dir,
+		options:  options,
+		index:    newKeyIndex[logLocation](),
+		segments: make(map[uint32]*logSegment),
+	}
+
+	err = logMap.load()
+	if err != nil {
+		logMap.Close()
+		return nil, err
+	}
+
+	if options.CompactionInterval > 0 {
+		logMap.stop = make(chan struct{})
+		logMap.stopped = make(chan struct{})
+		go logMap.compactPeriodically()
+	}
+
+	return logMap, nil
+}
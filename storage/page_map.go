@@ -0,0 +1,499 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	pageSize       = 4096
+	pageHeaderSize = 12 // type(1) padding(3) next page(4) used bytes(4)
+	pagePayload    = pageSize - pageHeaderSize
+	recordMetaSize = 20 // sequence(8) key length(4) value length(4) checksum(4)
+)
+
+const (
+	freePage     byte = 0
+	headPage     byte = 1
+	overflowPage byte = 2
+)
+
+var pageFileMagic = []byte("TSPAGE01")
+
+// PageMap keeps every key inside a single page structured data file.
+//
+// Each record (key & value) is stored in a chain of fixed size pages starting with a head page.
+// Writes never overwrite live pages: a new record is written to free pages and synced before the
+// previous record of the same key is released, so a crash always leaves either the old or the new
+// record intact. Head pages carry a sequence number and a checksum which are used to pick the
+// latest valid record when the file is reopened.
+type PageMap struct {
+	mut       sync.RWMutex
+	file      *os.File
+	index     *keyIndex[uint32] // key: record key, value: head page
+	freePages []uint32
+	pageCount uint32
+	nextSeq   uint64
+}
+
+var _ RawMap = (*PageMap)(nil)
+
+type pageRecord struct {
+	seq   uint64
+	key   string
+	value []byte
+	pages []uint32
+}
+
+func (p *PageMap) Get(key string) ([]byte, error) {
+	value, ok, err := p.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, KeyNotFound(key)
+	}
+
+	return value, nil
+}
+
+func (p *PageMap) Set(key string, data []byte) error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	return p.set(key, data)
+}
+
+func (p *PageMap) Contain(key string) (bool, error) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	return p.index.ContainDir(key), nil
+}
+
+// Delete removes the key together with all the keys nested under it.
+func (p *PageMap) Delete(key string) error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	return p.delete(key)
+}
+
+func (p *PageMap) Scan(prefix string) (Iterator, error) {
+	return p.ScanRange(prefix, prefixEnd(prefix))
+}
+
+func (p *PageMap) ScanRange(start string, end string) (Iterator, error) {
+	return newCursorIterator(start, end, func(key string, inclusive bool) (string, bool) {
+		p.mut.RLock()
+		defer p.mut.RUnlock()
+
+		return p.index.NextKey(key, inclusive)
+	}, p.lookup), nil
+}
+
+// Begin starts a batch which is journaled next to the page file before being applied,
+// so that a batch interrupted by a crash is redone when the map is opened again.
+func (p *PageMap) Begin() (Batch, error) {
+	return newStagedBatch(p, func(ops []batchOp) error {
+		p.mut.Lock()
+		defer p.mut.Unlock()
+
+		err := writeJournal(p.journalPath(), ops)
+		if err != nil {
+			return err
+		}
+
+		return p.redo(ops)
+	}), nil
+}
+
+func (p *PageMap) Close() error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	return p.file.Close()
+}
+
+func (p *PageMap) lookup(key string) ([]byte, bool, error) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	head, ok := p.index.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	record, err := p.readRecord(head)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return record.value, true, nil
+}
+
+func (p *PageMap) delete(key string) error {
+	keys := p.index.KeysUnder(key)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, currKey := range keys {
+		head, _ := p.index.Delete(currKey)
+		err := p.releaseRecord(head)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.file.Sync()
+}
+
+func (p *PageMap) redo(ops []batchOp) error {
+	for _, op := range ops {
+		var err error
+		if op.deleted {
+			err = p.delete(op.key)
+		} else {
+			err = p.set(op.key, op.data)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return removeJournal(p.journalPath())
+}
+
+func (p *PageMap) journalPath() string {
+	return p.file.Name() + journalExt
+}
+
+func (p *PageMap) set(key string, data []byte) error {
+	seq := p.nextSeq
+	p.nextSeq++
+
+	head, err := p.writeRecord(seq, key, data)
+	if err != nil {
+		return err
+	}
+
+	err = p.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	prevHead, exist := p.index.Get(key)
+	p.index.Set(key, head)
+	if !exist {
+		return nil
+	}
+
+	err = p.releaseRecord(prevHead)
+	if err != nil {
+		return err
+	}
+
+	return p.file.Sync()
+}
+
+func (p *PageMap) writeRecord(seq uint64, key string, data []byte) (uint32, error) {
+	buf := make([]byte, recordMetaSize, recordMetaSize+len(key)+len(data))
+	binary.LittleEndian.PutUint64(buf[0:8], seq)
+	binary.LittleEndian.PutUint32(buf[8:12], uint32(len(key)))
+	binary.LittleEndian.PutUint32(buf[12:16], uint32(len(data)))
+	buf = append(buf, key...)
+	buf = append(buf, data...)
+	binary.LittleEndian.PutUint32(buf[16:20], recordChecksum(buf))
+
+	pageNum := (len(buf) + pagePayload - 1) / pagePayload
+	pages := make([]uint32, pageNum)
+	for index := range pages {
+		pages[index] = p.allocatePage()
+	}
+
+	for index, page := range pages {
+		pageType := overflowPage
+		if index == 0 {
+			pageType = headPage
+		}
+
+		var next uint32
+		if index+1 < len(pages) {
+			next = pages[index+1]
+		}
+
+		end := (index + 1) * pagePayload
+		if end > len(buf) {
+			end = len(buf)
+		}
+
+		err := p.writePage(page, pageType, next, buf[index*pagePayload:end])
+		if err != nil {
+			p.freePages = append(p.freePages, pages...)
+			return 0, err
+		}
+	}
+
+	return pages[0], nil
+}
+
+func (p *PageMap) readRecord(head uint32) (pageRecord, error) {
+	pageType, next, payload, err := p.readPage(head)
+	if err != nil {
+		return pageRecord{}, err
+	}
+
+	if pageType != headPage || len(payload) < recordMetaSize {
+		return pageRecord{}, fmt.Errorf("invalid head page: page=%v", head)
+	}
+
+	keyLen := binary.LittleEndian.Uint32(payload[8:12])
+	valueLen := binary.LittleEndian.Uint32(payload[12:16])
+	total := recordMetaSize + int(keyLen) + int(valueLen)
+
+	buf := make([]byte, 0, total)
+	buf = append(buf, payload...)
+	pages := []uint32{head}
+	for len(buf) < total {
+		if next == 0 || len(pages) > int(p.pageCount) {
+			return pageRecord{}, fmt.Errorf("broken page chain: head=%v", head)
+		}
+
+		page := next
+		pageType, next, payload, err = p.readPage(page)
+		if err != nil {
+			return pageRecord{}, err
+		}
+
+		if pageType != overflowPage {
+			return pageRecord{}, fmt.Errorf("invalid overflow page: page=%v", page)
+		}
+
+		buf = append(buf, payload...)
+		pages = append(pages, page)
+	}
+
+	if len(buf) != total || binary.LittleEndian.Uint32(buf[16:20]) != recordChecksum(buf) {
+		return pageRecord{}, fmt.Errorf("record checksum mismatch: head=%v", head)
+	}
+
+	return pageRecord{
+		seq:   binary.LittleEndian.Uint64(buf[0:8]),
+		key:   string(buf[recordMetaSize : recordMetaSize+keyLen]),
+		value: buf[recordMetaSize+keyLen:],
+		pages: pages,
+	}, nil
+}
+
+func (p *PageMap) releaseRecord(head uint32) error {
+	pages, err := p.chainPages(head)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.file.WriteAt([]byte{freePage}, int64(head)*pageSize)
+	if err != nil {
+		return err
+	}
+
+	p.freePages = append(p.freePages, pages...)
+	return nil
+}
+
+func (p *PageMap) chainPages(head uint32) ([]uint32, error) {
+	pages := []uint32{head}
+	_, next, _, err := p.readPage(head)
+	if err != nil {
+		return nil, err
+	}
+
+	for next != 0 && len(pages) <= int(p.pageCount) {
+		pages = append(pages, next)
+		_, next, _, err = p.readPage(next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pages, nil
+}
+
+func (p *PageMap) allocatePage() uint32 {
+	if len(p.freePages) > 0 {
+		page := p.freePages[len(p.freePages)-1]
+		p.freePages = p.freePages[:len(p.freePages)-1]
+		return page
+	}
+
+	page := p.pageCount
+	p.pageCount++
+	return page
+}
+
+func (p *PageMap) writePage(page uint32, pageType byte, next uint32, payload []byte) error {
+	buf := make([]byte, pageSize)
+	buf[0] = pageType
+	binary.LittleEndian.PutUint32(buf[4:8], next)
+	binary.LittleEndian.PutUint32(buf[8:12], uint32(len(payload)))
+	copy(buf[pageHeaderSize:], payload)
+
+	_, err := p.file.WriteAt(buf, int64(page)*pageSize)
+	return err
+}
+
+func (p *PageMap) readPage(page uint32) (byte, uint32, []byte, error) {
+	buf := make([]byte, pageSize)
+	_, err := p.file.ReadAt(buf, int64(page)*pageSize)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	used := binary.LittleEndian.Uint32(buf[8:12])
+	if used > pagePayload {
+		return 0, 0, nil, fmt.Errorf("invalid page: page=%v", page)
+	}
+
+	return buf[0], binary.LittleEndian.Uint32(buf[4:8]), buf[pageHeaderSize : pageHeaderSize+used], nil
+}
+
+func (p *PageMap) load() error {
+	info, err := p.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() == 0 {
+		header := make([]byte, pageSize)
+		copy(header, pageFileMagic)
+		binary.LittleEndian.PutUint32(header[len(pageFileMagic):], pageSize)
+		_, err = p.file.WriteAt(header, 0)
+		if err != nil {
+			return err
+		}
+
+		p.pageCount = 1
+		return p.file.Sync()
+	}
+
+	header := make([]byte, len(pageFileMagic)+4)
+	_, err = p.file.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	if !bytes.Equal(header[:len(pageFileMagic)], pageFileMagic) ||
+		binary.LittleEndian.Uint32(header[len(pageFileMagic):]) != pageSize {
+		return fmt.Errorf("not a page file: %v", p.file.Name())
+	}
+
+	// a partially written trailing page is ignored and reused by the next allocation
+	p.pageCount = uint32(info.Size() / pageSize)
+
+	latest := make(map[string]pageRecord)
+	staleHeads := make([]uint32, 0)
+	for page := uint32(1); page < p.pageCount; page++ {
+		pageType, _, _, err := p.readPage(page)
+		if err != nil || pageType != headPage {
+			continue
+		}
+
+		record, err := p.readRecord(page)
+		if err != nil {
+			staleHeads = append(staleHeads, page)
+			continue
+		}
+
+		if prev, ok := latest[record.key]; ok {
+			if prev.seq > record.seq {
+				staleHeads = append(staleHeads, page)
+				continue
+			}
+
+			staleHeads = append(staleHeads, prev.pages[0])
+		}
+
+		latest[record.key] = record
+		if record.seq >= p.nextSeq {
+			p.nextSeq = record.seq + 1
+		}
+	}
+
+	livePages := make(map[uint32]bool)
+	for key, record := range latest {
+		p.index.Set(key, record.pages[0])
+		for _, page := range record.pages {
+			livePages[page] = true
+		}
+	}
+
+	for page := p.pageCount - 1; page > 0; page-- {
+		if !livePages[page] {
+			p.freePages = append(p.freePages, page)
+		}
+	}
+
+	if len(staleHeads) == 0 {
+		return nil
+	}
+
+	for _, page := range staleHeads {
+		_, err = p.file.WriteAt([]byte{freePage}, int64(page)*pageSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.file.Sync()
+}
+
+func recordChecksum(record []byte) uint32 {
+	checksum := crc32.NewIEEE()
+	checksum.Write(record[:16])
+	checksum.Write(record[recordMetaSize:])
+	return checksum.Sum32()
+}
+
+func NewPageMap(filePath string) (*PageMap, error) {
+	err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	pageMap := &PageMap{
+		file:  file,
+		index: newKeyIndex[uint32](),
+	}
+
+	err = pageMap.load()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	ops, ok, err := readJournal(pageMap.journalPath())
+	if ok {
+		err = pageMap.redo(ops)
+	}
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return pageMap, nil
+}
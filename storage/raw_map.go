@@ -1,6 +1,7 @@
 package storage
 
 type RawMap interface {
+	// Get returns an error matching ErrKeyNotFound when the key doesn't exist
 	Get(key string) ([]byte, error)
 	Set(key string, data []byte) error
 	Contain(key string) (bool, error)
@@ -0,0 +1,537 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rawMapFactory struct {
+	name   string
+	create func(t testing.TB, dir string) RawMap
+	// nested reports whether deleting a key also deletes the keys nested under it
+	nested bool
+}
+
+var rawMapFactories = []rawMapFactory{
+	{
+		name: "InMemoryMap",
+		create: func(t testing.TB, dir string) RawMap {
+			return NewInMemoryMap()
+		},
+		nested: true,
+	},
+	{
+		name: "FileMap",
+		create: func(t testing.TB, dir string) RawMap {
+			fileMap, err := NewFileMap(dir, DurabilityFull)
+			assert.Nil(t, err)
+			return fileMap
+		},
+		nested: true,
+	},
+	{
+		name: "PageMap",
+		create: func(t testing.TB, dir string) RawMap {
+			pageMap, err := NewPageMap(filepath.Join(dir, "data.tsp"))
+			assert.Nil(t, err)
+			return pageMap
+		},
+		nested: true,
+	},
+	{
+		name: "LogMap",
+		create: func(t testing.TB, dir string) RawMap {
+			options := DefaultLogMapOptions()
+			options.CompactionInterval = 0
+			logMap, err := NewLogMap(dir, options)
+			assert.Nil(t, err)
+			return logMap
+		},
+		nested: true,
+	},
+	{
+		name: "CompressedMap",
+		create: func(t testing.TB, dir string) RawMap {
+			compressedMap, err := NewCompressedMap(NewInMemoryMap(), SnappyCompression)
+			assert.Nil(t, err)
+			return compressedMap
+		},
+		nested: true,
+	},
+	{
+		name: "CachedMap",
+		create: func(t testing.TB, dir string) RawMap {
+			return NewCachedMap(NewInMemoryMap(), 4*pageSize)
+		},
+		nested: true,
+	},
+	{
+		name: "EncryptedMap",
+		create: func(t testing.TB, dir string) RawMap {
+			keys := EncryptionKeys{}
+			_, err := keys.Rotate()
+			assert.Nil(t, err)
+
+			encryptedMap, err := NewEncryptedMap(NewInMemoryMap(), keys)
+			assert.Nil(t, err)
+			return encryptedMap
+		},
+		nested: true,
+	},
+	{
+		name: "FaultyMap",
+		create: func(t testing.TB, dir string) RawMap {
+			return NewFaultyMap(NewInMemoryMap(), FailWritesPlan())
+		},
+		nested: true,
+	},
+}
+
+func TestRawMap(t *testing.T) {
+	for _, factory := range rawMapFactories {
+		t.Run(factory.name, func(t *testing.T) {
+			rawMap := factory.create(t, t.TempDir())
+
+			contain, err := rawMap.Contain("list/tail")
+			assert.Nil(t, err)
+			assert.False(t, contain)
+
+			assert.Nil(t, rawMap.Set("list/tail", []byte("\"list/nodes/1\"")))
+			assert.Nil(t, rawMap.Set("list/nodes/1/data", []byte("Harry")))
+
+			contain, err = rawMap.Contain("list/tail")
+			assert.Nil(t, err)
+			assert.True(t, contain)
+
+			buf, err := rawMap.Get("list/nodes/1/data")
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("Harry"), buf)
+
+			_, err = rawMap.Get("list/nodes/3/data")
+			assert.ErrorIs(t, err, ErrKeyNotFound)
+
+			assert.Nil(t, rawMap.Set("list/nodes/1/data", []byte("Potter")))
+			buf, err = rawMap.Get("list/nodes/1/data")
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("Potter"), buf)
+
+			large := make([]byte, 3*pageSize+7)
+			for index := range large {
+				large[index] = byte(index)
+			}
+
+			assert.Nil(t, rawMap.Set("list/nodes/2/data", large))
+			buf, err = rawMap.Get("list/nodes/2/data")
+			assert.Nil(t, err)
+			assert.Equal(t, large, buf)
+
+			assert.Nil(t, rawMap.Delete("list/tail"))
+			contain, err = rawMap.Contain("list/tail")
+			assert.Nil(t, err)
+			assert.False(t, contain)
+
+			// deleting a missing key is a no-op
+			assert.Nil(t, rawMap.Delete("missing/nodes/1"))
+
+			if !factory.nested {
+				return
+			}
+
+			contain, err = rawMap.Contain("list/nodes/1")
+			assert.Nil(t, err)
+			assert.True(t, contain)
+
+			assert.Nil(t, rawMap.Delete("list/nodes"))
+			for _, key := range []string{"list/nodes", "list/nodes/1/data", "list/nodes/2/data"} {
+				contain, err = rawMap.Contain(key)
+				assert.Nil(t, err)
+				assert.False(t, contain)
+			}
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	for _, factory := range rawMapFactories {
+		t.Run(factory.name, func(t *testing.T) {
+			rawMap := factory.create(t, t.TempDir())
+			assert.Nil(t, rawMap.Set("map/pairs/1", []byte("Harry")))
+			assert.Nil(t, rawMap.Set("map/pairs/2", []byte("Ron")))
+
+			batch, err := Begin(rawMap)
+			assert.Nil(t, err)
+			assert.Nil(t, batch.Set("map/pairs/3", []byte("Hermione")))
+			assert.Nil(t, batch.Delete("map/pairs/1"))
+
+			// staged operations are only visible through the batch
+			buf, err := batch.Get("map/pairs/3")
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("Hermione"), buf)
+
+			contain, err := batch.Contain("map/pairs/1")
+			assert.Nil(t, err)
+			assert.False(t, contain)
+
+			contain, err = rawMap.Contain("map/pairs/3")
+			assert.Nil(t, err)
+			assert.False(t, contain)
+
+			nested, err := batch.Begin()
+			assert.Nil(t, err)
+			assert.Nil(t, nested.Set("map/keys/3", []byte("3")))
+			assert.Nil(t, nested.Commit())
+			assert.Nil(t, batch.Commit())
+			assert.NotNil(t, batch.Commit())
+
+			expected := map[string]bool{
+				"map/pairs/1": false,
+				"map/pairs/2": true,
+				"map/pairs/3": true,
+				"map/keys/3":  true,
+			}
+			for key, exist := range expected {
+				contain, err = rawMap.Contain(key)
+				assert.Nil(t, err)
+				assert.Equal(t, exist, contain, key)
+			}
+
+			batch, err = Begin(rawMap)
+			assert.Nil(t, err)
+			assert.Nil(t, batch.Delete("map/pairs"))
+			assert.Nil(t, batch.Set("map/pairs/4", []byte("Ginny")))
+			assert.Nil(t, batch.Abort())
+
+			contain, err = rawMap.Contain("map/pairs/4")
+			assert.Nil(t, err)
+			assert.False(t, contain)
+
+			batch, err = Begin(rawMap)
+			assert.Nil(t, err)
+			assert.Nil(t, batch.Set("map/pairs/4", []byte("Ginny")))
+			assert.Nil(t, batch.Delete("map/pairs"))
+			assert.Nil(t, batch.Commit())
+			for _, key := range []string{"map/pairs/2", "map/pairs/3", "map/pairs/4"} {
+				contain, err = rawMap.Contain(key)
+				assert.Nil(t, err)
+				assert.False(t, contain, key)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	for _, factory := range rawMapFactories {
+		t.Run(factory.name, func(t *testing.T) {
+			rawMap := factory.create(t, t.TempDir())
+			for _, key := range []string{"map/pairs/b", "map/pairs/a", "map/pairs/c/d", "map/pairsX", "map/keys/a"} {
+				assert.Nil(t, rawMap.Set(key, []byte(key)))
+			}
+
+			iterator, err := Scan(rawMap, "map/pairs/")
+			assert.Nil(t, err)
+
+			keys := make([]string, 0)
+			for iterator.Next() {
+				assert.Equal(t, []byte(iterator.Key()), iterator.Value())
+				keys = append(keys, iterator.Key())
+			}
+
+			assert.Nil(t, iterator.Err())
+			assert.Nil(t, iterator.Close())
+			assert.Equal(t, []string{"map/pairs/a", "map/pairs/b", "map/pairs/c/d"}, keys)
+
+			iterator, err = ScanRange(rawMap, "map/keys/a", "map/pairs/b")
+			assert.Nil(t, err)
+			assert.True(t, iterator.Next())
+			assert.Equal(t, "map/keys/a", iterator.Key())
+
+			// closing early stops the iteration
+			assert.Nil(t, iterator.Close())
+			assert.False(t, iterator.Next())
+
+			keys, err = ScanKeys(rawMap, "")
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"map/keys/a", "map/pairs/a", "map/pairs/b", "map/pairs/c/d", "map/pairsX"}, keys)
+
+			batch, err := Begin(rawMap)
+			assert.Nil(t, err)
+			assert.Nil(t, batch.Delete("map/pairs/c"))
+			assert.Nil(t, batch.Set("map/pairs/0", []byte("map/pairs/0")))
+
+			keys, err = ScanKeys(batch, "map/pairs/")
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"map/pairs/0", "map/pairs/a", "map/pairs/b"}, keys)
+			assert.Nil(t, batch.Abort())
+		})
+	}
+}
+
+func TestBatch_RedoJournal(t *testing.T) {
+	ops := []batchOp{
+		{key: "map/pairs/1", deleted: true},
+		{key: "map/pairs/2", data: []byte("Ron")},
+	}
+
+	dir := t.TempDir()
+	fileMap, err := NewFileMap(dir, DurabilityFull)
+	assert.Nil(t, err)
+	assert.Nil(t, fileMap.Set("map/pairs/1", []byte("Harry")))
+
+	// crash after the journal is written
+	assert.Nil(t, writeJournal(fileMap.journalPath(), ops))
+	fileMap, err = NewFileMap(dir, DurabilityFull)
+	assert.Nil(t, err)
+
+	contain, err := fileMap.Contain("map/pairs/1")
+	assert.Nil(t, err)
+	assert.False(t, contain)
+
+	buf, err := fileMap.Get("map/pairs/2")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("Ron"), buf)
+
+	filePath := filepath.Join(t.TempDir(), "data.tsp")
+	pageMap, err := NewPageMap(filePath)
+	assert.Nil(t, err)
+	assert.Nil(t, pageMap.Set("map/pairs/1", []byte("Harry")))
+	assert.Nil(t, writeJournal(pageMap.journalPath(), ops))
+	assert.Nil(t, pageMap.Close())
+
+	pageMap, err = NewPageMap(filePath)
+	assert.Nil(t, err)
+	defer pageMap.Close()
+
+	contain, err = pageMap.Contain("map/pairs/1")
+	assert.Nil(t, err)
+	assert.False(t, contain)
+
+	buf, err = pageMap.Get("map/pairs/2")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("Ron"), buf)
+}
+
+func TestLogMap_TornBatch(t *testing.T) {
+	dir := t.TempDir()
+	options := DefaultLogMapOptions()
+	options.CompactionInterval = 0
+	logMap, err := NewLogMap(dir, options)
+	assert.Nil(t, err)
+	assert.Nil(t, logMap.Set("map/pairs/1", []byte("Harry")))
+
+	batch, err := logMap.Begin()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Delete("map/pairs/1"))
+	assert.Nil(t, batch.Set("map/pairs/2", []byte("Ron")))
+	assert.Nil(t, batch.Set("map/pairs/3", []byte("Hermione")))
+	assert.Nil(t, batch.Commit())
+
+	// crash before the last record of the batch reaches the disk
+	active := logMap.active
+	assert.Nil(t, active.file.Truncate(active.size-1))
+	assert.Nil(t, logMap.Close())
+
+	logMap, err = NewLogMap(dir, options)
+	assert.Nil(t, err)
+	defer logMap.Close()
+
+	buf, err := logMap.Get("map/pairs/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("Harry"), buf)
+
+	for _, key := range []string{"map/pairs/2", "map/pairs/3"} {
+		contain, err := logMap.Contain(key)
+		assert.Nil(t, err)
+		assert.False(t, contain)
+	}
+}
+
+func TestFileMap_Checksum(t *testing.T) {
+	dir := t.TempDir()
+	fileMap, err := NewFileMap(dir, DurabilityFull)
+	assert.Nil(t, err)
+	assert.Nil(t, fileMap.Set("map/pairs/1", []byte("\"Harry\"")))
+
+	// no temporary file is left behind
+	fileInfos, err := ioutil.ReadDir(filepath.Join(dir, "map", "pairs"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(fileInfos))
+
+	filePath := filepath.Join(dir, "map", "pairs", "1")
+	buf, err := ioutil.ReadFile(filePath)
+	assert.Nil(t, err)
+
+	buf[len(buf)-2] = 'X'
+	assert.Nil(t, ioutil.WriteFile(filePath, buf, 0644))
+	_, err = fileMap.Get("map/pairs/1")
+	assert.Equal(t, CorruptedValue("map/pairs/1"), err)
+
+	// torn write
+	assert.Nil(t, ioutil.WriteFile(filePath, buf[:5], 0644))
+	_, err = fileMap.Get("map/pairs/1")
+	assert.Equal(t, CorruptedValue("map/pairs/1"), err)
+
+	// a value without checksum is corrupted in a directory created since checksums were added
+	assert.Nil(t, ioutil.WriteFile(filePath, []byte("\"Potter\""), 0644))
+	_, err = fileMap.Get("map/pairs/1")
+	assert.Equal(t, CorruptedValue("map/pairs/1"), err)
+}
+
+func TestFileMap_LegacyValues(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "map", "pairs", "1")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(filePath), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filePath, []byte("\"Potter\""), 0644))
+
+	// values written before checksums were added are read as is
+	fileMap, err := NewFileMap(dir, DurabilityFull)
+	assert.Nil(t, err)
+	buf, err := fileMap.Get("map/pairs/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\"Potter\""), buf)
+
+	iterator, err := fileMap.Scan("")
+	assert.Nil(t, err)
+	assert.True(t, iterator.Next())
+	assert.Equal(t, "map/pairs/1", iterator.Key())
+	assert.False(t, iterator.Next())
+	assert.Nil(t, iterator.Close())
+
+	// a checksummed value with a corrupted leading byte is not mistaken for a legacy value
+	assert.Nil(t, fileMap.Set("map/pairs/1", []byte("\"Harry\"")))
+	buf, err = ioutil.ReadFile(filePath)
+	assert.Nil(t, err)
+	buf[0] = 'X'
+	assert.Nil(t, ioutil.WriteFile(filePath, buf, 0644))
+	_, err = fileMap.Get("map/pairs/1")
+	assert.Equal(t, CorruptedValue("map/pairs/1"), err)
+}
+
+func TestPageMap_Reopen(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "data.tsp")
+	pageMap, err := NewPageMap(filePath)
+	assert.Nil(t, err)
+
+	large := make([]byte, 2*pageSize)
+	for index := 0; index < 100; index++ {
+		assert.Nil(t, pageMap.Set(fmt.Sprintf("keys/%v", index), []byte(fmt.Sprintf("value%v", index))))
+	}
+
+	assert.Nil(t, pageMap.Set("keys/7", large))
+	assert.Nil(t, pageMap.Delete("keys/3"))
+	assert.Nil(t, pageMap.Close())
+
+	pageMap, err = NewPageMap(filePath)
+	assert.Nil(t, err)
+	defer pageMap.Close()
+
+	buf, err := pageMap.Get("keys/42")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value42"), buf)
+
+	buf, err = pageMap.Get("keys/7")
+	assert.Nil(t, err)
+	assert.Equal(t, large, buf)
+
+	contain, err := pageMap.Contain("keys/3")
+	assert.Nil(t, err)
+	assert.False(t, contain)
+
+	// released pages are reused instead of growing the file
+	pageCount := pageMap.pageCount
+	assert.Nil(t, pageMap.Set("keys/8", []byte("reused")))
+	assert.Equal(t, pageCount, pageMap.pageCount)
+}
+
+func TestLogMap_Compact(t *testing.T) {
+	dir := t.TempDir()
+	options := DefaultLogMapOptions()
+	options.MaxSegmentSize = 512
+	options.CompactionInterval = 0
+	logMap, err := NewLogMap(dir, options)
+	assert.Nil(t, err)
+
+	for round := 0; round < 20; round++ {
+		for index := 0; index < 10; index++ {
+			key := fmt.Sprintf("idGens/%v", index)
+			assert.Nil(t, logMap.Set(key, []byte(fmt.Sprintf("%v", round))))
+		}
+	}
+
+	assert.Nil(t, logMap.Delete("idGens/3"))
+	assert.True(t, len(logMap.segments) > 2)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for index := 0; index < 50; index++ {
+			assert.Nil(t, logMap.Set("idGens/0", []byte(fmt.Sprintf("concurrent%v", index))))
+		}
+	}()
+
+	assert.Nil(t, logMap.Compact())
+	<-done
+	assert.Nil(t, logMap.Close())
+
+	// restart from the hint file of the merged segment
+	logMap, err = NewLogMap(dir, options)
+	assert.Nil(t, err)
+	defer logMap.Close()
+
+	buf, err := logMap.Get("idGens/0")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("concurrent49"), buf)
+
+	buf, err = logMap.Get("idGens/9")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("19"), buf)
+
+	contain, err := logMap.Contain("idGens/3")
+	assert.Nil(t, err)
+	assert.False(t, contain)
+	assert.True(t, logMap.liveBytes <= logMap.totalBytes)
+}
+
+func BenchmarkRawMap_Set(b *testing.B) {
+	for _, factory := range rawMapFactories {
+		b.Run(factory.name, func(b *testing.B) {
+			rawMap := factory.create(b, b.TempDir())
+			value := []byte("\"databases/data/example/nodes/42\"")
+
+			b.ResetTimer()
+			for index := 0; index < b.N; index++ {
+				err := rawMap.Set(fmt.Sprintf("list/nodes/%v/next", index%1000), value)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRawMap_Get(b *testing.B) {
+	for _, factory := range rawMapFactories {
+		b.Run(factory.name, func(b *testing.B) {
+			rawMap := factory.create(b, b.TempDir())
+			value := []byte("\"databases/data/example/nodes/42\"")
+			for index := 0; index < 1000; index++ {
+				err := rawMap.Set(fmt.Sprintf("list/nodes/%v/next", index), value)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ResetTimer()
+			for index := 0; index < b.N; index++ {
+				_, err := rawMap.Get(fmt.Sprintf("list/nodes/%v/next", index%1000))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
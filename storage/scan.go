@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Iterator walks through keys in lexical order.
+//
+//	for iterator.Next() {
+//		iterator.Key(), iterator.Value()
+//	}
+//	err := iterator.Err()
+type Iterator interface {
+	Next() bool
+	Key() string
+	Value() []byte
+	Err() error
+	Close() error
+}
+
+// Scanner is implemented by the RawMaps which can enumerate their keys in lexical order.
+type Scanner interface {
+	// Scan iterates through the keys starting with prefix
+	Scan(prefix string) (Iterator, error)
+	// ScanRange iterates through the keys within [start, end). An empty end means no upper bound.
+	ScanRange(start string, end string) (Iterator, error)
+}
+
+func Scan(rawMap RawMap, prefix string) (Iterator, error) {
+	scanner, ok := rawMap.(Scanner)
+	if !ok {
+		return nil, fmt.Errorf("scan not supported: %T", rawMap)
+	}
+
+	return scanner.Scan(prefix)
+}
+
+func ScanRange(rawMap RawMap, start string, end string) (Iterator, error) {
+	scanner, ok := rawMap.(Scanner)
+	if !ok {
+		return nil, fmt.Errorf("scan not supported: %T", rawMap)
+	}
+
+	return scanner.ScanRange(start, end)
+}
+
+// ScanKeys returns all the keys starting with prefix.
+func ScanKeys(rawMap RawMap, prefix string) ([]string, error) {
+	iterator, err := Scan(rawMap, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	keys := make([]string, 0)
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	return keys, iterator.Err()
+}
+
+// prefixEnd returns the smallest key which is larger than all the keys starting with prefix.
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for index := len(end) - 1; index >= 0; index-- {
+		if end[index] < 0xff {
+			end[index]++
+			return string(end[:index+1])
+		}
+	}
+
+	// every key starts with an empty prefix
+	return ""
+}
+
+// commonDir returns the deepest directory containing every key within [start, end)
+func commonDir(start string, end string) string {
+	if end == "" {
+		return ""
+	}
+
+	length := 0
+	for length < len(start) && length < len(end) && start[length] == end[length] {
+		length++
+	}
+
+	index := strings.LastIndex(start[:length], "/")
+	if index < 0 {
+		return ""
+	}
+
+	return start[:index]
+}
+
+func inRange(key string, start string, end string) bool {
+	return key >= start && (end == "" || key < end)
+}
+
+// keyIterator loads the value of every key produced by nextKey.
+// Keys removed since they were produced are skipped.
+type keyIterator struct {
+	nextKey func() (string, bool, error)
+	get     func(key string) ([]byte, bool, error)
+	key     string
+	value   []byte
+	err     error
+	closed  bool
+}
+
+var _ Iterator = (*keyIterator)(nil)
+
+func (k *keyIterator) Next() bool {
+	if k.closed || k.err != nil {
+		return false
+	}
+
+	for {
+		key, ok, err := k.nextKey()
+		if err != nil {
+			k.err = err
+			return false
+		}
+
+		if !ok {
+			k.closed = true
+			return false
+		}
+
+		value, exist, err := k.get(key)
+		if err != nil {
+			k.err = err
+			return false
+		}
+
+		if !exist {
+			continue
+		}
+
+		k.key = key
+		k.value = value
+		return true
+	}
+}
+
+func (k *keyIterator) Key() string {
+	return k.key
+}
+
+func (k *keyIterator) Value() []byte {
+	return k.value
+}
+
+func (k *keyIterator) Err() error {
+	return k.err
+}
+
+func (k *keyIterator) Close() error {
+	k.closed = true
+	k.value = nil
+	return nil
+}
+
+// newSnapshotIterator iterates through a sorted copy of keys taken at creation time
+func newSnapshotIterator(keys []string, get func(key string) ([]byte, bool, error)) *keyIterator {
+	sort.Strings(keys)
+	return &keyIterator{
+		nextKey: func() (string, bool, error) {
+			if len(keys) == 0 {
+				return "", false, nil
+			}
+
+			key := keys[0]
+			keys = keys[1:]
+			return key, true, nil
+		},
+		get: get,
+	}
+}
+
+// newCursorIterator iterates through the keys of a keyIndex which may change during the iteration.
+// nextKey is called with the last returned key to find the key after it.
+func newCursorIterator(
+	start string,
+	end string,
+	nextKey func(key string, inclusive bool) (string, bool),
+	get func(key string) ([]byte, bool, error),
+) *keyIterator {
+	cursor := start
+	inclusive := true
+	return &keyIterator{
+		nextKey: func() (string, bool, error) {
+			key, ok := nextKey(cursor, inclusive)
+			if !ok || !inRange(key, start, end) {
+				return "", false, nil
+			}
+
+			cursor = key
+			inclusive = false
+			return key, true, nil
+		},
+		get: get,
+	}
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/golang/snappy"
+)
+
+var errCorruptedSnappy = errors.New("corrupted snappy block")
+
+// snappyCompressor implements the snappy block format, which favors speed over compression ratio.
+type snappyCompressor struct {
+}
+
+func (s snappyCompressor) Compress(data []byte) ([]byte, error) {
+	return snappy.Encode(nil, data), nil
+}
+
+func (s snappyCompressor) Decompress(buf []byte) ([]byte, error) {
+	length, err := snappy.DecodedLen(buf)
+	if err != nil {
+		return nil, errCorruptedSnappy
+	}
+
+	// the length of a corrupted block can't be trusted for the allocation,
+	// each byte of a valid block decodes to at most 64 bytes
+	if length > len(buf)*64 {
+		return nil, errCorruptedSnappy
+	}
+
+	data, err := snappy.Decode(make([]byte, length), buf)
+	if err != nil {
+		return nil, errCorruptedSnappy
+	}
+
+	return data, nil
+}
@@ -0,0 +1,114 @@
+package storage
+
+// transformedMap is the base of the RawMap decorators which rewrite values:
+// encode is applied before a value is stored and decode after it is loaded.
+// Keys are passed through unchanged, so batches & scans of the underlying map keep working.
+type transformedMap struct {
+	rawMap RawMap
+	encode func(key string, data []byte) ([]byte, error)
+	decode func(key string, buf []byte) ([]byte, error)
+}
+
+func (t transformedMap) Get(key string) ([]byte, error) {
+	buf, err := t.rawMap.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.decode(key, buf)
+}
+
+func (t transformedMap) Set(key string, data []byte) error {
+	buf, err := t.encode(key, data)
+	if err != nil {
+		return err
+	}
+
+	return t.rawMap.Set(key, buf)
+}
+
+func (t transformedMap) Contain(key string) (bool, error) {
+	return t.rawMap.Contain(key)
+}
+
+func (t transformedMap) Delete(key string) error {
+	return t.rawMap.Delete(key)
+}
+
+func (t transformedMap) Scan(prefix string) (Iterator, error) {
+	iterator, err := Scan(t.rawMap, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transformedIterator{Iterator: iterator, decode: t.decode}, nil
+}
+
+func (t transformedMap) ScanRange(start string, end string) (Iterator, error) {
+	iterator, err := ScanRange(t.rawMap, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transformedIterator{Iterator: iterator, decode: t.decode}, nil
+}
+
+// Begin stages the encoded values in a batch of the underlying map.
+func (t transformedMap) Begin() (Batch, error) {
+	batch, err := Begin(t.rawMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformedBatch{
+		transformedMap: transformedMap{
+			rawMap: batch,
+			encode: t.encode,
+			decode: t.decode,
+		},
+		batch: batch,
+	}, nil
+}
+
+type transformedBatch struct {
+	transformedMap
+	batch Batch
+}
+
+var _ Batch = (*transformedBatch)(nil)
+
+func (t transformedBatch) Commit() error {
+	return t.batch.Commit()
+}
+
+func (t transformedBatch) Abort() error {
+	return t.batch.Abort()
+}
+
+type transformedIterator struct {
+	Iterator
+	decode func(key string, buf []byte) ([]byte, error)
+	value  []byte
+	err    error
+}
+
+func (t *transformedIterator) Next() bool {
+	if t.err != nil || !t.Iterator.Next() {
+		return false
+	}
+
+	t.value, t.err = t.decode(t.Iterator.Key(), t.Iterator.Value())
+	return t.err == nil
+}
+
+func (t *transformedIterator) Value() []byte {
+	return t.value
+}
+
+func (t *transformedIterator) Err() error {
+	if t.err != nil {
+		return t.err
+	}
+
+	return t.Iterator.Err()
+}